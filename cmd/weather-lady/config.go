@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/caarlos0/env/v11"
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+	"google.golang.org/grpc/credentials"
+)
+
+type config struct {
+	DiscordToken                string        `env:"DISCORD_TOKEN,required"`
+	DatabaseDSN                 string        `env:"DATABASE_DSN,required"`
+	WebCaptureAddress           string        `env:"WEB_CAPTURE_ADDRESS"           envDefault:"localhost:50051"`
+	WebCaptureTLS               bool          `env:"WEB_CAPTURE_TLS"               envDefault:"false"`
+	WebCaptureCAFile            string        `env:"WEB_CAPTURE_CA_FILE"`
+	SkipHealthCheck             bool          `env:"SKIP_HEALTH_CHECK"             envDefault:"false"`
+	HealthCheckTimeout          time.Duration `env:"HEALTH_CHECK_TIMEOUT"          envDefault:"30s"`
+	DiscordStatuses             []string      `env:"DISCORD_STATUSES"              envDefault:"the skies ☁️" envSeparator:";"`
+	DiscordStatusRotation       time.Duration `env:"DISCORD_STATUS_ROTATION"       envDefault:"10m"`
+	DevGuildID                  string        `env:"DEV_GUILD_ID"`
+	WelcomeMessage              bool          `env:"WELCOME_MESSAGE"               envDefault:"false"`
+	DiscordOpenAttempts         int           `env:"DISCORD_OPEN_ATTEMPTS"         envDefault:"5"`
+	DiscordOpenRetryDelay       time.Duration `env:"DISCORD_OPEN_RETRY_DELAY"      envDefault:"2s"`
+	ScheduleJitter              time.Duration `env:"SCHEDULE_JITTER"               envDefault:"0"`
+	WebhookUsername             string        `env:"WEBHOOK_USERNAME"`
+	WebhookAvatarURL            string        `env:"WEBHOOK_AVATAR_URL"`
+	DeliveryLagThreshold        time.Duration `env:"DELIVERY_LAG_THRESHOLD"        envDefault:"1m"`
+	DeliveryEmbeds              bool          `env:"DELIVERY_EMBEDS"               envDefault:"false"`
+	SendAttempts                int           `env:"SEND_ATTEMPTS"                 envDefault:"3"`
+	SendRetryDelay              time.Duration `env:"SEND_RETRY_DELAY"              envDefault:"1s"`
+	DeliveryWebhookURL          string        `env:"DELIVERY_WEBHOOK_URL"`
+	DeliveryWebhookTimeout      time.Duration `env:"DELIVERY_WEBHOOK_TIMEOUT"      envDefault:"5s"`
+	StaleFallbackMaxAge         time.Duration `env:"STALE_FALLBACK_MAX_AGE"        envDefault:"0"`
+	MaxConsecutiveFailures      int           `env:"MAX_CONSECUTIVE_FAILURES"      envDefault:"0"`
+	FailureAlertThreshold       int           `env:"FAILURE_ALERT_THRESHOLD"       envDefault:"0"`
+	CaptureAttempts             int           `env:"CAPTURE_ATTEMPTS"              envDefault:"1"`
+	CaptureRetryDelay           time.Duration `env:"CAPTURE_RETRY_DELAY"           envDefault:"2s"`
+	CaptureCacheTTL             time.Duration `env:"CAPTURE_CACHE_TTL"             envDefault:"0"`
+	GuildCapturesPerHour        int           `env:"GUILD_CAPTURES_PER_HOUR"       envDefault:"0"`
+	ScheduledCaptureConcurrency int           `env:"SCHEDULED_CAPTURE_CONCURRENCY" envDefault:"0"`
+	OnDemandCaptureConcurrency  int           `env:"ON_DEMAND_CAPTURE_CONCURRENCY" envDefault:"0"`
+	LoadConcurrency             int           `env:"LOAD_CONCURRENCY"              envDefault:"50"`
+	CatchUpWindow               time.Duration `env:"CATCH_UP_WINDOW"               envDefault:"0"`
+	DeliveryLocks               bool          `env:"DELIVERY_LOCKS"                envDefault:"false"`
+	InstanceID                  string        `env:"INSTANCE_ID"`
+	ShutdownTimeout             time.Duration `env:"SHUTDOWN_TIMEOUT"              envDefault:"10s"`
+	LogFormat                   string        `env:"LOG_FORMAT"                    envDefault:"text"`
+	MetricsAddress              string        `env:"METRICS_ADDRESS"`
+	SubscriptionPermission      string        `env:"SUBSCRIPTION_PERMISSION"       envDefault:"manage_channels"`
+	SubscriptionRoleIDs         []string      `env:"SUBSCRIPTION_ROLE_IDS"`
+	ForecastTemplateFile        string        `env:"FORECAST_TEMPLATE_FILE"`
+	ArchiveDir                  string        `env:"ARCHIVE_DIR"`
+
+	// Settings below may be changed at runtime with /admin-reload-config.
+	DefaultForecastURL      string        `env:"DEFAULT_FORECAST_URL"      envDefault:"https://tenki.jp/#forecast-public-date-entry-2"`
+	DefaultForecastSelector string        `env:"DEFAULT_FORECAST_SELECTOR" envDefault:"#forecast-map-wrap"`
+	LatestForecastURL       string        `env:"LATEST_FORECAST_URL"       envDefault:"https://tenki.jp/"`
+	CaptureTimeout          time.Duration `env:"CAPTURE_TIMEOUT"           envDefault:"30s"`
+	DispatchTimeout         time.Duration `env:"DISPATCH_TIMEOUT"          envDefault:"30s"`
+	LogLevel                string        `env:"LOG_LEVEL"                 envDefault:"info"`
+}
+
+// loadConfig parses configuration from the process environment. When ENV_FILE names a file of
+// KEY=VALUE lines, its entries override the environment, which lets a running bot pick up edits
+// on reload.
+func loadConfig() (config, error) {
+	environment := env.ToMap(os.Environ())
+	if path := environment["ENV_FILE"]; path != "" {
+		overrides, err := readEnvFile(path)
+		if err != nil {
+			return config{}, err
+		}
+		maps.Copy(environment, overrides)
+	}
+
+	return env.ParseAsWithOptions[config](env.Options{Environment: environment})
+}
+
+func readEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open env file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("env file %s line %d: expected KEY=VALUE", path, lineNumber)
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+
+	return values, nil
+}
+
+func (c config) logLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, err
+	}
+	return level, nil
+}
+
+// logLevelVar is the minimum level of the JSON log handler; the default handler's level is set
+// alongside it.
+var logLevelVar = new(slog.LevelVar)
+
+// configureLogging installs the handler selected by LOG_FORMAT: "text" keeps the default
+// line-oriented output and "json" emits one JSON object per entry for log aggregation.
+func (c config) configureLogging(level slog.Level) error {
+	switch strings.ToLower(strings.TrimSpace(c.LogFormat)) {
+	case "", "text":
+	case "json":
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level: logLevelVar,
+		})))
+	default:
+		return fmt.Errorf("unsupported LOG_FORMAT %q: use text or json", c.LogFormat)
+	}
+
+	setLogLevel(level)
+	return nil
+}
+
+func setLogLevel(level slog.Level) {
+	logLevelVar.Set(level)
+	slog.SetLogLoggerLevel(level)
+}
+
+// captureCredentials returns the transport credentials for the capture service, or nil for an
+// unencrypted connection. Without a CA file the system roots verify the server.
+func (c config) captureCredentials() (credentials.TransportCredentials, error) {
+	if !c.WebCaptureTLS {
+		if c.WebCaptureCAFile != "" {
+			return nil, fmt.Errorf("WEB_CAPTURE_CA_FILE requires WEB_CAPTURE_TLS=true")
+		}
+		return nil, nil
+	}
+	if c.WebCaptureCAFile == "" {
+		return credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}), nil
+	}
+
+	creds, err := credentials.NewClientTLSFromFile(c.WebCaptureCAFile, "")
+	if err != nil {
+		return nil, fmt.Errorf("load capture service CA: %w", err)
+	}
+	return creds, nil
+}
+
+// subscriptionPermission returns the Discord permission members need to change a channel's
+// subscriptions, or zero when anyone may.
+func (c config) subscriptionPermission() (int64, error) {
+	switch strings.ToLower(strings.TrimSpace(c.SubscriptionPermission)) {
+	case "manage_channels":
+		return discordgo.PermissionManageChannels, nil
+	case "manage_guild":
+		return discordgo.PermissionManageGuild, nil
+	case "none":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf(
+			"unsupported SUBSCRIPTION_PERMISSION %q: use manage_channels, manage_guild or none",
+			c.SubscriptionPermission,
+		)
+	}
+}
+
+// instanceID returns the name this instance records with claimed deliveries, falling back to the
+// hostname when INSTANCE_ID is unset.
+func (c config) instanceID() (string, error) {
+	if id := strings.TrimSpace(c.InstanceID); id != "" {
+		return id, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("INSTANCE_ID is unset and the hostname is unavailable: %w", err)
+	}
+	return hostname, nil
+}
+
+// validateSources rejects default forecast URLs that subscriptions could not be captured from.
+// Unset values keep the bot's built-in defaults.
+func (c config) validateSources() error {
+	sources := []struct{ name, value string }{
+		{"DEFAULT_FORECAST_URL", c.DefaultForecastURL},
+		{"LATEST_FORECAST_URL", c.LatestForecastURL},
+	}
+	for _, source := range sources {
+		if source.value == "" {
+			continue
+		}
+		if _, err := domain.ParseSourceURL(source.value); err != nil {
+			return fmt.Errorf("%s: %w", source.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c config) settings() usecase.Settings {
+	return usecase.Settings{
+		DefaultForecastURL:      c.DefaultForecastURL,
+		DefaultForecastSelector: c.DefaultForecastSelector,
+		LatestForecastURL:       c.LatestForecastURL,
+		CaptureTimeout:          c.CaptureTimeout,
+		DispatchTimeout:         c.DispatchTimeout,
+		LogLevel:                c.LogLevel,
+	}
+}
+
+// reloadSettings re-reads the configuration and applies the runtime-adjustable settings.
+func reloadSettings(holder *usecase.SettingsHolder) ([]string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := cfg.logLevel()
+	if err != nil {
+		return nil, fmt.Errorf("parse log level: %w", err)
+	}
+	if err := cfg.validateSources(); err != nil {
+		return nil, err
+	}
+
+	changed := holder.Replace(cfg.settings())
+	setLogLevel(level)
+
+	return changed, nil
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"html/template"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/caarlos0/env/v11"
 	"github.com/sglre6355/weather-lady/internal/domain"
 	"github.com/sglre6355/weather-lady/internal/infrastructure"
 	"github.com/sglre6355/weather-lady/internal/infrastructure/database"
@@ -16,19 +20,28 @@ import (
 	"github.com/sglre6355/weather-lady/internal/usecase"
 )
 
-type config struct {
-	DiscordToken      string `env:"DISCORD_TOKEN,required"`
-	DatabaseDSN       string `env:"DATABASE_DSN,required"`
-	WebCaptureAddress string `env:"WEB_CAPTURE_ADDRESS"    envDefault:"localhost:50051"`
-}
-
 func run() int {
-	cfg, err := env.ParseAs[config]()
+	cfg, err := loadConfig()
 	if err != nil {
 		slog.Error("failed to parse environment variables", slog.Any("error", err))
 		return 1
 	}
 
+	level, err := cfg.logLevel()
+	if err != nil {
+		slog.Error("failed to parse log level", slog.Any("error", err))
+		return 1
+	}
+	if err := cfg.configureLogging(level); err != nil {
+		slog.Error("failed to configure logging", slog.Any("error", err))
+		return 1
+	}
+	if err := cfg.validateSources(); err != nil {
+		slog.Error("invalid forecast source", slog.Any("error", err))
+		return 1
+	}
+	settings := usecase.NewSettingsHolder(cfg.settings())
+
 	db, err := database.Open(cfg.DatabaseDSN)
 	if err != nil {
 		slog.Error("failed to connect to database", slog.Any("error", err))
@@ -47,12 +60,26 @@ func run() int {
 	}()
 
 	subscriptionStore := database.NewSubscriptionStore(db)
-	if err := subscriptionStore.AutoMigrate(context.Background()); err != nil {
+	if err := subscriptionStore.Migrate(context.Background()); err != nil {
 		slog.Error("failed to run database migrations", slog.Any("error", err))
 		return 1
 	}
 
-	weatherService, err := infrastructure.NewWeatherService(cfg.WebCaptureAddress)
+	subscriptionPermission, err := cfg.subscriptionPermission()
+	if err != nil {
+		slog.Error("invalid subscription permission", slog.Any("error", err))
+		return 1
+	}
+
+	captureCredentials, err := cfg.captureCredentials()
+	if err != nil {
+		slog.Error("failed to configure capture service TLS", slog.Any("error", err))
+		return 1
+	}
+	weatherService, err := infrastructure.NewWeatherService(
+		cfg.WebCaptureAddress,
+		infrastructure.WithTransportCredentials(captureCredentials),
+	)
 	if err != nil {
 		slog.Error("failed to create weather service", slog.Any("error", err))
 		return 1
@@ -63,7 +90,23 @@ func run() int {
 		}
 	}()
 
-	weatherUsecase := usecase.NewWeatherUsecase(weatherService)
+	var usecaseOpts []usecase.WeatherUsecaseOption
+	if cfg.ForecastTemplateFile != "" {
+		forecastTemplate, err := template.ParseFiles(cfg.ForecastTemplateFile)
+		if err != nil {
+			slog.Error("failed to parse forecast template", slog.Any("error", err))
+			return 1
+		}
+		usecaseOpts = append(usecaseOpts, usecase.WithForecastTemplate(forecastTemplate))
+	}
+
+	weatherUsecase := usecase.NewWeatherUsecase(weatherService, usecaseOpts...)
+	var captureSource usecase.ForecastCapture = weatherUsecase
+	if cfg.CaptureCacheTTL > 0 {
+		captureSource = usecase.NewCaptureCache(weatherUsecase, cfg.CaptureCacheTTL)
+	}
+	scheduledCapture := usecase.NewCapturePool(captureSource, cfg.ScheduledCaptureConcurrency)
+	onDemandCapture := usecase.NewCapturePool(captureSource, cfg.OnDemandCaptureConcurrency)
 
 	session, err := discordgo.New("Bot " + cfg.DiscordToken)
 	if err != nil {
@@ -80,12 +123,107 @@ func run() int {
 		}
 	}()
 
-	forecastSender := presentation.NewDiscordForecastSender(session)
+	forecastSender := presentation.NewDiscordForecastSender(
+		session,
+		presentation.WithEmbeds(cfg.DeliveryEmbeds),
+		presentation.WithSendRetries(cfg.SendAttempts, cfg.SendRetryDelay),
+	)
 
-	subscriptionManager := usecase.NewSubscriptionManager(
-		weatherUsecase,
-		forecastSender,
+	var (
+		managerOpts []usecase.SubscriptionManagerOption
+		botOpts     []presentation.WeatherBotOption
+	)
+	if cfg.GuildCapturesPerHour > 0 {
+		limiter := usecase.NewGuildCaptureLimiter(cfg.GuildCapturesPerHour)
+		managerOpts = append(managerOpts, usecase.WithCaptureLimiter(limiter))
+		botOpts = append(botOpts, presentation.WithCaptureLimiter(limiter))
+	}
+
+	if cfg.DeliveryLocks {
+		instanceID, err := cfg.instanceID()
+		if err != nil {
+			slog.Error("failed to name instance", slog.Any("error", err))
+			return 1
+		}
+		managerOpts = append(managerOpts, usecase.WithDeliveryLocks(subscriptionStore, instanceID))
+	}
+
+	var metrics *infrastructure.PrometheusMetrics
+	if cfg.MetricsAddress != "" {
+		metrics = infrastructure.NewPrometheusMetrics()
+		managerOpts = append(managerOpts, usecase.WithSubscriptionMetrics(metrics))
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			defer cancel()
+
+			if err := weatherService.Ping(ctx); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("ok\n"))
+		})
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			defer cancel()
+
+			session.RLock()
+			discordReady := session.DataReady
+			session.RUnlock()
+			if !discordReady {
+				http.Error(w, "discord session is not connected", http.StatusServiceUnavailable)
+				return
+			}
+			if err := weatherService.Ping(ctx); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("ok\n"))
+		})
+		metricsServer := &http.Server{
+			Addr:              cfg.MetricsAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil &&
+				!errors.Is(err, http.ErrServerClosed) {
+				slog.Error("metrics server stopped", slog.Any("error", err))
+			}
+		}()
+		defer func() {
+			if err := metricsServer.Close(); err != nil {
+				slog.Error("failed to close metrics server", slog.Any("error", err))
+			}
+		}()
+	}
+
+	var deliveryWebhook *infrastructure.DeliveryWebhook
+	if cfg.DeliveryWebhookURL != "" {
+		deliveryWebhook = infrastructure.NewDeliveryWebhook(
+			cfg.DeliveryWebhookURL,
+			cfg.DeliveryWebhookTimeout,
+		)
+	}
+
+	managerOpts = append(managerOpts,
 		usecase.WithSubscriptionStore(subscriptionStore),
+		usecase.WithSubscriberNotifier(forecastSender),
+		usecase.WithErrorNotices(forecastSender),
+		usecase.WithTextExtractor(weatherUsecase),
+		usecase.WithCaptureFeatures(weatherUsecase),
+		usecase.WithSettings(settings),
+		usecase.WithOnDemandCapture(onDemandCapture),
+		usecase.WithStaleFallback(cfg.StaleFallbackMaxAge),
+		usecase.WithMaxConsecutiveFailures(cfg.MaxConsecutiveFailures),
+		usecase.WithFailureAlerts(cfg.FailureAlertThreshold),
+		usecase.WithCaptureRetries(cfg.CaptureAttempts, cfg.CaptureRetryDelay),
+		usecase.WithShutdownTimeout(cfg.ShutdownTimeout),
+		usecase.WithScheduleJitter(cfg.ScheduleJitter),
+		usecase.WithLoadConcurrency(cfg.LoadConcurrency),
+		usecase.WithCatchUpWindow(cfg.CatchUpWindow),
 		usecase.WithSubscriptionErrorHandler(
 			func(sub domain.Subscription, stage usecase.SubscriptionErrorStage, err error) {
 				slog.Error(
@@ -94,21 +232,110 @@ func run() int {
 					slog.Any("stage", stage),
 					slog.Any("error", err),
 				)
+
+				if metrics != nil {
+					metrics.DeliveryFailed(string(stage))
+				}
+				if deliveryWebhook == nil {
+					return
+				}
+				if webhookErr := deliveryWebhook.NotifyFailure(
+					context.Background(),
+					sub,
+					string(stage),
+					err,
+				); webhookErr != nil {
+					slog.Warn("failed to notify delivery webhook", slog.Any("error", webhookErr))
+				}
+			},
+		),
+		usecase.WithSubscriptionDeliveryHandler(func(sub domain.Subscription) {
+			if deliveryWebhook == nil {
+				return
+			}
+			if err := deliveryWebhook.NotifySuccess(context.Background(), sub); err != nil {
+				slog.Warn("failed to notify delivery webhook", slog.Any("error", err))
+			}
+		}),
+		usecase.WithCaptureStatsHandler(
+			func(sub domain.Subscription, duration time.Duration, size int, err error) {
+				if metrics != nil {
+					metrics.CaptureObserved(duration, size, err)
+				}
+				slog.Debug(
+					"forecast captured",
+					slog.String("channel", sub.ChannelID),
+					slog.Duration("duration", duration),
+					slog.Int("bytes", size),
+					slog.Any("error", err),
+				)
 			},
 		),
+		usecase.WithDeliveryLagHandler(func(sub domain.Subscription, lag time.Duration) {
+			if lag < cfg.DeliveryLagThreshold {
+				return
+			}
+			slog.Warn(
+				"subscription delivery fired late",
+				slog.String("channel", sub.ChannelID),
+				slog.Duration("lag", lag),
+			)
+		}),
+	)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var scheduledSender usecase.ForecastSender = presentation.NewWebhookForecastSender(
+		session,
+		forecastSender,
+		presentation.WithWebhookIdentity(cfg.WebhookUsername, cfg.WebhookAvatarURL),
+		presentation.WithWebhookSendRetries(cfg.SendAttempts, cfg.SendRetryDelay),
+	)
+	if cfg.ArchiveDir != "" {
+		scheduledSender = usecase.NewMultiSender(
+			scheduledSender,
+			infrastructure.NewFileForecastSender(cfg.ArchiveDir),
+		)
+	}
+
+	subscriptionManager := usecase.NewSubscriptionManager(
+		scheduledCapture,
+		scheduledSender,
+		managerOpts...,
 	)
 
-	if err := subscriptionManager.LoadExisting(context.Background()); err != nil {
+	if err := subscriptionManager.LoadExisting(ctx); err != nil {
 		slog.Error("failed to restore saved subscriptions", "error", err)
 		return 1
 	}
 
-	bot, err := presentation.NewWeatherBot(session, subscriptionManager, weatherUsecase)
+	botOpts = append(botOpts,
+		presentation.WithPresence(cfg.DiscordStatuses, cfg.DiscordStatusRotation),
+		presentation.WithWelcomeMessage(cfg.WelcomeMessage),
+		presentation.WithCommandGuild(cfg.DevGuildID),
+		presentation.WithOpenRetry(cfg.DiscordOpenAttempts, cfg.DiscordOpenRetryDelay),
+		presentation.WithSettings(settings),
+		presentation.WithSubscriptionPermission(subscriptionPermission, cfg.SubscriptionRoleIDs),
+		presentation.WithConfigReloader(func() ([]string, error) {
+			return reloadSettings(settings)
+		}),
+	)
+	bot, err := presentation.NewWeatherBot(session, subscriptionManager, onDemandCapture, botOpts...)
 	if err != nil {
 		slog.Error("failed to create bot", "error", err)
 		return 1
 	}
 
+	if !cfg.SkipHealthCheck {
+		if err := waitForCaptureService(weatherService, cfg.HealthCheckTimeout); err != nil {
+			slog.Warn(
+				"capture service is not reachable, starting anyway; forecasts will fail until it is",
+				slog.String("address", cfg.WebCaptureAddress),
+				slog.Any("error", err),
+			)
+		}
+	}
+
 	if err := bot.Start(); err != nil {
 		bot.Stop()
 		slog.Error("failed to start bot", "error", err)
@@ -121,9 +348,13 @@ func run() int {
 		return 1
 	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
-	<-stop
+	if err := subscriptionManager.Start(ctx); err != nil {
+		bot.Stop()
+		slog.Error("failed to start subscription manager", "error", err)
+		return 1
+	}
+
+	<-ctx.Done()
 
 	slog.Info("Termination signal received, shutting down...")
 	bot.Stop()
@@ -132,6 +363,32 @@ func run() int {
 	return 0
 }
 
+// captureServiceRetryInterval is how often an unreachable capture service is retried at startup.
+const captureServiceRetryInterval = 2 * time.Second
+
+// waitForCaptureService blocks until the capture service is reachable or timeout elapses, and
+// returns the last error in the latter case.
+func waitForCaptureService(
+	weatherService *infrastructure.WeatherService,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		err := weatherService.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(captureServiceRetryInterval):
+		}
+	}
+}
+
 func main() {
 	os.Exit(run())
 }
@@ -131,6 +131,79 @@ func (InteractionType) EnumDescriptor() ([]byte, []int) {
 	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{1}
 }
 
+type Capability int32
+
+const (
+	Capability_CAPABILITY_UNSPECIFIED     Capability = 0
+	Capability_CAPABILITY_HEADERS         Capability = 1  // CaptureElementRequest.headers
+	Capability_CAPABILITY_TIMEZONE        Capability = 2  // CaptureElementRequest.timezone_id
+	Capability_CAPABILITY_CLIP            Capability = 3  // CaptureElementRequest.clip
+	Capability_CAPABILITY_MATCH_INDEX     Capability = 4  // CaptureElementRequest.match_index
+	Capability_CAPABILITY_QUALITY         Capability = 5  // CaptureElementRequest.quality
+	Capability_CAPABILITY_FULL_PAGE       Capability = 6  // CaptureElementRequest.full_page
+	Capability_CAPABILITY_VIEWPORT        Capability = 7  // CaptureElementRequest.viewport_width and viewport_height
+	Capability_CAPABILITY_SCALE_FACTOR    Capability = 8  // CaptureElementRequest.scale_factor
+	Capability_CAPABILITY_RENDER_DOCUMENT Capability = 9  // The RenderDocument RPC
+	Capability_CAPABILITY_EXTRACT_TEXT    Capability = 10 // The ExtractText RPC
+)
+
+// Enum value maps for Capability.
+var (
+	Capability_name = map[int32]string{
+		0:  "CAPABILITY_UNSPECIFIED",
+		1:  "CAPABILITY_HEADERS",
+		2:  "CAPABILITY_TIMEZONE",
+		3:  "CAPABILITY_CLIP",
+		4:  "CAPABILITY_MATCH_INDEX",
+		5:  "CAPABILITY_QUALITY",
+		6:  "CAPABILITY_FULL_PAGE",
+		7:  "CAPABILITY_VIEWPORT",
+		8:  "CAPABILITY_SCALE_FACTOR",
+		9:  "CAPABILITY_RENDER_DOCUMENT",
+		10: "CAPABILITY_EXTRACT_TEXT",
+	}
+	Capability_value = map[string]int32{
+		"CAPABILITY_UNSPECIFIED":     0,
+		"CAPABILITY_HEADERS":         1,
+		"CAPABILITY_TIMEZONE":        2,
+		"CAPABILITY_CLIP":            3,
+		"CAPABILITY_MATCH_INDEX":     4,
+		"CAPABILITY_QUALITY":         5,
+		"CAPABILITY_FULL_PAGE":       6,
+		"CAPABILITY_VIEWPORT":        7,
+		"CAPABILITY_SCALE_FACTOR":    8,
+		"CAPABILITY_RENDER_DOCUMENT": 9,
+		"CAPABILITY_EXTRACT_TEXT":    10,
+	}
+)
+
+func (x Capability) Enum() *Capability {
+	p := new(Capability)
+	*p = x
+	return p
+}
+
+func (x Capability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Capability) Descriptor() protoreflect.EnumDescriptor {
+	return file_web_capture_v1_web_capture_proto_enumTypes[2].Descriptor()
+}
+
+func (Capability) Type() protoreflect.EnumType {
+	return &file_web_capture_v1_web_capture_proto_enumTypes[2]
+}
+
+func (x Capability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Capability.Descriptor instead.
+func (Capability) EnumDescriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{2}
+}
+
 type Interaction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          InteractionType        `protobuf:"varint,1,opt,name=type,proto3,enum=web_capture.v1.InteractionType" json:"type,omitempty"`
@@ -205,6 +278,15 @@ type CaptureElementRequest struct {
 	ElementSelector string                 `protobuf:"bytes,2,opt,name=element_selector,json=elementSelector,proto3" json:"element_selector,omitempty"`
 	ImageFormat     ImageFormat            `protobuf:"varint,3,opt,name=image_format,json=imageFormat,proto3,enum=web_capture.v1.ImageFormat" json:"image_format,omitempty"`
 	Interactions    []*Interaction         `protobuf:"bytes,4,rep,name=interactions,proto3" json:"interactions,omitempty"`
+	Headers         map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Extra HTTP headers sent when loading url (e.g. Accept-Language)
+	TimezoneId      string                 `protobuf:"bytes,6,opt,name=timezone_id,json=timezoneId,proto3" json:"timezone_id,omitempty"`                                                   // IANA timezone emulated by the browser; empty uses the service's zone
+	Clip            *ClipRegion            `protobuf:"bytes,7,opt,name=clip,proto3" json:"clip,omitempty"`                                                                                 // Page rectangle captured instead of element_selector when set
+	MatchIndex      int32                  `protobuf:"varint,8,opt,name=match_index,json=matchIndex,proto3" json:"match_index,omitempty"`                                                  // Zero-based index among the elements matching element_selector
+	Quality         int32                  `protobuf:"varint,9,opt,name=quality,proto3" json:"quality,omitempty"`                                                                          // Encoding quality (1-100) for lossy image formats; ignored for PNG
+	FullPage        bool                   `protobuf:"varint,10,opt,name=full_page,json=fullPage,proto3" json:"full_page,omitempty"`                                                       // Capture the whole scrollable page; element_selector must be empty
+	ViewportWidth   int32                  `protobuf:"varint,11,opt,name=viewport_width,json=viewportWidth,proto3" json:"viewport_width,omitempty"`                                        // Window width in CSS pixels; zero uses the service's default
+	ViewportHeight  int32                  `protobuf:"varint,12,opt,name=viewport_height,json=viewportHeight,proto3" json:"viewport_height,omitempty"`                                     // Window height in CSS pixels; zero uses the service's default
+	ScaleFactor     float64                `protobuf:"fixed64,13,opt,name=scale_factor,json=scaleFactor,proto3" json:"scale_factor,omitempty"`                                             // Device scale factor; zero uses the service's default
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -267,6 +349,137 @@ func (x *CaptureElementRequest) GetInteractions() []*Interaction {
 	return nil
 }
 
+func (x *CaptureElementRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *CaptureElementRequest) GetTimezoneId() string {
+	if x != nil {
+		return x.TimezoneId
+	}
+	return ""
+}
+
+func (x *CaptureElementRequest) GetClip() *ClipRegion {
+	if x != nil {
+		return x.Clip
+	}
+	return nil
+}
+
+func (x *CaptureElementRequest) GetMatchIndex() int32 {
+	if x != nil {
+		return x.MatchIndex
+	}
+	return 0
+}
+
+func (x *CaptureElementRequest) GetQuality() int32 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+func (x *CaptureElementRequest) GetFullPage() bool {
+	if x != nil {
+		return x.FullPage
+	}
+	return false
+}
+
+func (x *CaptureElementRequest) GetViewportWidth() int32 {
+	if x != nil {
+		return x.ViewportWidth
+	}
+	return 0
+}
+
+func (x *CaptureElementRequest) GetViewportHeight() int32 {
+	if x != nil {
+		return x.ViewportHeight
+	}
+	return 0
+}
+
+func (x *CaptureElementRequest) GetScaleFactor() float64 {
+	if x != nil {
+		return x.ScaleFactor
+	}
+	return 0
+}
+
+type ClipRegion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClipRegion) Reset() {
+	*x = ClipRegion{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClipRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClipRegion) ProtoMessage() {}
+
+func (x *ClipRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClipRegion.ProtoReflect.Descriptor instead.
+func (*ClipRegion) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClipRegion) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *ClipRegion) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *ClipRegion) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ClipRegion) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
 type CaptureElementResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -278,7 +491,7 @@ type CaptureElementResponse struct {
 
 func (x *CaptureElementResponse) Reset() {
 	*x = CaptureElementResponse{}
-	mi := &file_web_capture_v1_web_capture_proto_msgTypes[2]
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -290,7 +503,7 @@ func (x *CaptureElementResponse) String() string {
 func (*CaptureElementResponse) ProtoMessage() {}
 
 func (x *CaptureElementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_web_capture_v1_web_capture_proto_msgTypes[2]
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +516,7 @@ func (x *CaptureElementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CaptureElementResponse.ProtoReflect.Descriptor instead.
 func (*CaptureElementResponse) Descriptor() ([]byte, []int) {
-	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{2}
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CaptureElementResponse) GetTimestamp() int64 {
@@ -327,6 +540,334 @@ func (x *CaptureElementResponse) GetImageData() []byte {
 	return nil
 }
 
+type RenderDocumentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Html            string                 `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`                                              // Complete HTML document to render
+	ElementSelector string                 `protobuf:"bytes,2,opt,name=element_selector,json=elementSelector,proto3" json:"element_selector,omitempty"` // Element to capture; empty captures the whole document
+	ImageFormat     ImageFormat            `protobuf:"varint,3,opt,name=image_format,json=imageFormat,proto3,enum=web_capture.v1.ImageFormat" json:"image_format,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RenderDocumentRequest) Reset() {
+	*x = RenderDocumentRequest{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderDocumentRequest) ProtoMessage() {}
+
+func (x *RenderDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderDocumentRequest.ProtoReflect.Descriptor instead.
+func (*RenderDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenderDocumentRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *RenderDocumentRequest) GetElementSelector() string {
+	if x != nil {
+		return x.ElementSelector
+	}
+	return ""
+}
+
+func (x *RenderDocumentRequest) GetImageFormat() ImageFormat {
+	if x != nil {
+		return x.ImageFormat
+	}
+	return ImageFormat_IMAGE_FORMAT_UNSPECIFIED
+}
+
+type RenderDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ImageFormat   ImageFormat            `protobuf:"varint,2,opt,name=image_format,json=imageFormat,proto3,enum=web_capture.v1.ImageFormat" json:"image_format,omitempty"`
+	ImageData     []byte                 `protobuf:"bytes,3,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderDocumentResponse) Reset() {
+	*x = RenderDocumentResponse{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderDocumentResponse) ProtoMessage() {}
+
+func (x *RenderDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderDocumentResponse.ProtoReflect.Descriptor instead.
+func (*RenderDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenderDocumentResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RenderDocumentResponse) GetImageFormat() ImageFormat {
+	if x != nil {
+		return x.ImageFormat
+	}
+	return ImageFormat_IMAGE_FORMAT_UNSPECIFIED
+}
+
+func (x *RenderDocumentResponse) GetImageData() []byte {
+	if x != nil {
+		return x.ImageData
+	}
+	return nil
+}
+
+type ExtractTextRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Url             string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ElementSelector string                 `protobuf:"bytes,2,opt,name=element_selector,json=elementSelector,proto3" json:"element_selector,omitempty"` // Element whose rendered text is returned
+	Headers         map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TimezoneId      string                 `protobuf:"bytes,4,opt,name=timezone_id,json=timezoneId,proto3" json:"timezone_id,omitempty"`
+	MatchIndex      int32                  `protobuf:"varint,5,opt,name=match_index,json=matchIndex,proto3" json:"match_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExtractTextRequest) Reset() {
+	*x = ExtractTextRequest{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractTextRequest) ProtoMessage() {}
+
+func (x *ExtractTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractTextRequest.ProtoReflect.Descriptor instead.
+func (*ExtractTextRequest) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExtractTextRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExtractTextRequest) GetElementSelector() string {
+	if x != nil {
+		return x.ElementSelector
+	}
+	return ""
+}
+
+func (x *ExtractTextRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ExtractTextRequest) GetTimezoneId() string {
+	if x != nil {
+		return x.TimezoneId
+	}
+	return ""
+}
+
+func (x *ExtractTextRequest) GetMatchIndex() int32 {
+	if x != nil {
+		return x.MatchIndex
+	}
+	return 0
+}
+
+type ExtractTextResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"` // Visible text of the element, as rendered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractTextResponse) Reset() {
+	*x = ExtractTextResponse{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractTextResponse) ProtoMessage() {}
+
+func (x *ExtractTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractTextResponse.ProtoReflect.Descriptor instead.
+func (*ExtractTextResponse) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExtractTextResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ExtractTextResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{8}
+}
+
+type GetCapabilitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Capabilities  []Capability           `protobuf:"varint,1,rep,packed,name=capabilities,proto3,enum=web_capture.v1.Capability" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesResponse) ProtoMessage() {}
+
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_web_capture_v1_web_capture_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_web_capture_v1_web_capture_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCapabilitiesResponse) GetCapabilities() []Capability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 var File_web_capture_v1_web_capture_proto protoreflect.FileDescriptor
 
 const file_web_capture_v1_web_capture_proto_rawDesc = "" +
@@ -336,17 +877,64 @@ const file_web_capture_v1_web_capture_proto_rawDesc = "" +
 	"\x04type\x18\x01 \x01(\x0e2\x1f.web_capture.v1.InteractionTypeR\x04type\x12\x1a\n" +
 	"\bselector\x18\x02 \x01(\tR\bselector\x12\x14\n" +
 	"\x05value\x18\x03 \x01(\tR\x05value\x12\x17\n" +
-	"\await_ms\x18\x04 \x01(\x05R\x06waitMs\"\xd5\x01\n" +
+	"\await_ms\x18\x04 \x01(\x05R\x06waitMs\"\xfb\x04\n" +
 	"\x15CaptureElementRequest\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12)\n" +
 	"\x10element_selector\x18\x02 \x01(\tR\x0felementSelector\x12>\n" +
 	"\fimage_format\x18\x03 \x01(\x0e2\x1b.web_capture.v1.ImageFormatR\vimageFormat\x12?\n" +
-	"\finteractions\x18\x04 \x03(\v2\x1b.web_capture.v1.InteractionR\finteractions\"\x95\x01\n" +
+	"\finteractions\x18\x04 \x03(\v2\x1b.web_capture.v1.InteractionR\finteractions\x12L\n" +
+	"\aheaders\x18\x05 \x03(\v22.web_capture.v1.CaptureElementRequest.HeadersEntryR\aheaders\x12\x1f\n" +
+	"\vtimezone_id\x18\x06 \x01(\tR\n" +
+	"timezoneId\x12.\n" +
+	"\x04clip\x18\a \x01(\v2\x1a.web_capture.v1.ClipRegionR\x04clip\x12\x1f\n" +
+	"\vmatch_index\x18\b \x01(\x05R\n" +
+	"matchIndex\x12\x18\n" +
+	"\aquality\x18\t \x01(\x05R\aquality\x12\x1b\n" +
+	"\tfull_page\x18\n" +
+	" \x01(\bR\bfullPage\x12%\n" +
+	"\x0eviewport_width\x18\v \x01(\x05R\rviewportWidth\x12'\n" +
+	"\x0fviewport_height\x18\f \x01(\x05R\x0eviewportHeight\x12!\n" +
+	"\fscale_factor\x18\r \x01(\x01R\vscaleFactor\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"V\n" +
+	"\n" +
+	"ClipRegion\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\"\x95\x01\n" +
 	"\x16CaptureElementResponse\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12>\n" +
 	"\fimage_format\x18\x02 \x01(\x0e2\x1b.web_capture.v1.ImageFormatR\vimageFormat\x12\x1d\n" +
 	"\n" +
-	"image_data\x18\x03 \x01(\fR\timageData*o\n" +
+	"image_data\x18\x03 \x01(\fR\timageData\"\x96\x01\n" +
+	"\x15RenderDocumentRequest\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\x12)\n" +
+	"\x10element_selector\x18\x02 \x01(\tR\x0felementSelector\x12>\n" +
+	"\fimage_format\x18\x03 \x01(\x0e2\x1b.web_capture.v1.ImageFormatR\vimageFormat\"\x95\x01\n" +
+	"\x16RenderDocumentResponse\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12>\n" +
+	"\fimage_format\x18\x02 \x01(\x0e2\x1b.web_capture.v1.ImageFormatR\vimageFormat\x12\x1d\n" +
+	"\n" +
+	"image_data\x18\x03 \x01(\fR\timageData\"\x9a\x02\n" +
+	"\x12ExtractTextRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12)\n" +
+	"\x10element_selector\x18\x02 \x01(\tR\x0felementSelector\x12I\n" +
+	"\aheaders\x18\x03 \x03(\v2/.web_capture.v1.ExtractTextRequest.HeadersEntryR\aheaders\x12\x1f\n" +
+	"\vtimezone_id\x18\x04 \x01(\tR\n" +
+	"timezoneId\x12\x1f\n" +
+	"\vmatch_index\x18\x05 \x01(\x05R\n" +
+	"matchIndex\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x13ExtractTextResponse\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\x18\n" +
+	"\x16GetCapabilitiesRequest\"Y\n" +
+	"\x17GetCapabilitiesResponse\x12>\n" +
+	"\fcapabilities\x18\x01 \x03(\x0e2\x1a.web_capture.v1.CapabilityR\fcapabilities*o\n" +
 	"\vImageFormat\x12\x1c\n" +
 	"\x18IMAGE_FORMAT_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10IMAGE_FORMAT_PNG\x10\x01\x12\x15\n" +
@@ -358,9 +946,26 @@ const file_web_capture_v1_web_capture_proto_rawDesc = "" +
 	"\x15INTERACTION_TYPE_TYPE\x10\x02\x12\x19\n" +
 	"\x15INTERACTION_TYPE_WAIT\x10\x03\x12\x1b\n" +
 	"\x17INTERACTION_TYPE_SCROLL\x10\x04\x12\x1a\n" +
-	"\x16INTERACTION_TYPE_HOVER\x10\x052t\n" +
+	"\x16INTERACTION_TYPE_HOVER\x10\x05*\xaf\x02\n" +
+	"\n" +
+	"Capability\x12\x1a\n" +
+	"\x16CAPABILITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12CAPABILITY_HEADERS\x10\x01\x12\x17\n" +
+	"\x13CAPABILITY_TIMEZONE\x10\x02\x12\x13\n" +
+	"\x0fCAPABILITY_CLIP\x10\x03\x12\x1a\n" +
+	"\x16CAPABILITY_MATCH_INDEX\x10\x04\x12\x16\n" +
+	"\x12CAPABILITY_QUALITY\x10\x05\x12\x18\n" +
+	"\x14CAPABILITY_FULL_PAGE\x10\x06\x12\x17\n" +
+	"\x13CAPABILITY_VIEWPORT\x10\a\x12\x1b\n" +
+	"\x17CAPABILITY_SCALE_FACTOR\x10\b\x12\x1e\n" +
+	"\x1aCAPABILITY_RENDER_DOCUMENT\x10\t\x12\x1b\n" +
+	"\x17CAPABILITY_EXTRACT_TEXT\x10\n" +
+	"2\x91\x03\n" +
 	"\x11WebCaptureService\x12_\n" +
-	"\x0eCaptureElement\x12%.web_capture.v1.CaptureElementRequest\x1a&.web_capture.v1.CaptureElementResponseB\xbe\x01\n" +
+	"\x0eCaptureElement\x12%.web_capture.v1.CaptureElementRequest\x1a&.web_capture.v1.CaptureElementResponse\x12_\n" +
+	"\x0eRenderDocument\x12%.web_capture.v1.RenderDocumentRequest\x1a&.web_capture.v1.RenderDocumentResponse\x12V\n" +
+	"\vExtractText\x12\".web_capture.v1.ExtractTextRequest\x1a#.web_capture.v1.ExtractTextResponse\x12b\n" +
+	"\x0fGetCapabilities\x12&.web_capture.v1.GetCapabilitiesRequest\x1a'.web_capture.v1.GetCapabilitiesResponseB\xbe\x01\n" +
 	"\x12com.web_capture.v1B\x0fWebCaptureProtoP\x01ZBgithub.com/sglre6355/weather-lady/gen/web_capture/v1;web_capturev1\xa2\x02\x03WXX\xaa\x02\rWebCapture.V1\xca\x02\rWebCapture\\V1\xe2\x02\x19WebCapture\\V1\\GPBMetadata\xea\x02\x0eWebCapture::V1b\x06proto3"
 
 var (
@@ -375,27 +980,49 @@ func file_web_capture_v1_web_capture_proto_rawDescGZIP() []byte {
 	return file_web_capture_v1_web_capture_proto_rawDescData
 }
 
-var file_web_capture_v1_web_capture_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_web_capture_v1_web_capture_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_web_capture_v1_web_capture_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_web_capture_v1_web_capture_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_web_capture_v1_web_capture_proto_goTypes = []any{
-	(ImageFormat)(0),               // 0: web_capture.v1.ImageFormat
-	(InteractionType)(0),           // 1: web_capture.v1.InteractionType
-	(*Interaction)(nil),            // 2: web_capture.v1.Interaction
-	(*CaptureElementRequest)(nil),  // 3: web_capture.v1.CaptureElementRequest
-	(*CaptureElementResponse)(nil), // 4: web_capture.v1.CaptureElementResponse
+	(ImageFormat)(0),                // 0: web_capture.v1.ImageFormat
+	(InteractionType)(0),            // 1: web_capture.v1.InteractionType
+	(Capability)(0),                 // 2: web_capture.v1.Capability
+	(*Interaction)(nil),             // 3: web_capture.v1.Interaction
+	(*CaptureElementRequest)(nil),   // 4: web_capture.v1.CaptureElementRequest
+	(*ClipRegion)(nil),              // 5: web_capture.v1.ClipRegion
+	(*CaptureElementResponse)(nil),  // 6: web_capture.v1.CaptureElementResponse
+	(*RenderDocumentRequest)(nil),   // 7: web_capture.v1.RenderDocumentRequest
+	(*RenderDocumentResponse)(nil),  // 8: web_capture.v1.RenderDocumentResponse
+	(*ExtractTextRequest)(nil),      // 9: web_capture.v1.ExtractTextRequest
+	(*ExtractTextResponse)(nil),     // 10: web_capture.v1.ExtractTextResponse
+	(*GetCapabilitiesRequest)(nil),  // 11: web_capture.v1.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil), // 12: web_capture.v1.GetCapabilitiesResponse
+	nil,                             // 13: web_capture.v1.CaptureElementRequest.HeadersEntry
+	nil,                             // 14: web_capture.v1.ExtractTextRequest.HeadersEntry
 }
 var file_web_capture_v1_web_capture_proto_depIdxs = []int32{
-	1, // 0: web_capture.v1.Interaction.type:type_name -> web_capture.v1.InteractionType
-	0, // 1: web_capture.v1.CaptureElementRequest.image_format:type_name -> web_capture.v1.ImageFormat
-	2, // 2: web_capture.v1.CaptureElementRequest.interactions:type_name -> web_capture.v1.Interaction
-	0, // 3: web_capture.v1.CaptureElementResponse.image_format:type_name -> web_capture.v1.ImageFormat
-	3, // 4: web_capture.v1.WebCaptureService.CaptureElement:input_type -> web_capture.v1.CaptureElementRequest
-	4, // 5: web_capture.v1.WebCaptureService.CaptureElement:output_type -> web_capture.v1.CaptureElementResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1,  // 0: web_capture.v1.Interaction.type:type_name -> web_capture.v1.InteractionType
+	0,  // 1: web_capture.v1.CaptureElementRequest.image_format:type_name -> web_capture.v1.ImageFormat
+	3,  // 2: web_capture.v1.CaptureElementRequest.interactions:type_name -> web_capture.v1.Interaction
+	13, // 3: web_capture.v1.CaptureElementRequest.headers:type_name -> web_capture.v1.CaptureElementRequest.HeadersEntry
+	5,  // 4: web_capture.v1.CaptureElementRequest.clip:type_name -> web_capture.v1.ClipRegion
+	0,  // 5: web_capture.v1.CaptureElementResponse.image_format:type_name -> web_capture.v1.ImageFormat
+	0,  // 6: web_capture.v1.RenderDocumentRequest.image_format:type_name -> web_capture.v1.ImageFormat
+	0,  // 7: web_capture.v1.RenderDocumentResponse.image_format:type_name -> web_capture.v1.ImageFormat
+	14, // 8: web_capture.v1.ExtractTextRequest.headers:type_name -> web_capture.v1.ExtractTextRequest.HeadersEntry
+	2,  // 9: web_capture.v1.GetCapabilitiesResponse.capabilities:type_name -> web_capture.v1.Capability
+	4,  // 10: web_capture.v1.WebCaptureService.CaptureElement:input_type -> web_capture.v1.CaptureElementRequest
+	7,  // 11: web_capture.v1.WebCaptureService.RenderDocument:input_type -> web_capture.v1.RenderDocumentRequest
+	9,  // 12: web_capture.v1.WebCaptureService.ExtractText:input_type -> web_capture.v1.ExtractTextRequest
+	11, // 13: web_capture.v1.WebCaptureService.GetCapabilities:input_type -> web_capture.v1.GetCapabilitiesRequest
+	6,  // 14: web_capture.v1.WebCaptureService.CaptureElement:output_type -> web_capture.v1.CaptureElementResponse
+	8,  // 15: web_capture.v1.WebCaptureService.RenderDocument:output_type -> web_capture.v1.RenderDocumentResponse
+	10, // 16: web_capture.v1.WebCaptureService.ExtractText:output_type -> web_capture.v1.ExtractTextResponse
+	12, // 17: web_capture.v1.WebCaptureService.GetCapabilities:output_type -> web_capture.v1.GetCapabilitiesResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_web_capture_v1_web_capture_proto_init() }
@@ -408,8 +1035,8 @@ func file_web_capture_v1_web_capture_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_web_capture_v1_web_capture_proto_rawDesc), len(file_web_capture_v1_web_capture_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WebCaptureService_CaptureElement_FullMethodName = "/web_capture.v1.WebCaptureService/CaptureElement"
+	WebCaptureService_CaptureElement_FullMethodName  = "/web_capture.v1.WebCaptureService/CaptureElement"
+	WebCaptureService_RenderDocument_FullMethodName  = "/web_capture.v1.WebCaptureService/RenderDocument"
+	WebCaptureService_ExtractText_FullMethodName     = "/web_capture.v1.WebCaptureService/ExtractText"
+	WebCaptureService_GetCapabilities_FullMethodName = "/web_capture.v1.WebCaptureService/GetCapabilities"
 )
 
 // WebCaptureServiceClient is the client API for WebCaptureService service.
@@ -27,6 +30,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WebCaptureServiceClient interface {
 	CaptureElement(ctx context.Context, in *CaptureElementRequest, opts ...grpc.CallOption) (*CaptureElementResponse, error)
+	RenderDocument(ctx context.Context, in *RenderDocumentRequest, opts ...grpc.CallOption) (*RenderDocumentResponse, error)
+	ExtractText(ctx context.Context, in *ExtractTextRequest, opts ...grpc.CallOption) (*ExtractTextResponse, error)
+	// GetCapabilities lists the optional features the service implements. Services predating a
+	// feature ignore its request fields, so clients check here before relying on them.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 }
 
 type webCaptureServiceClient struct {
@@ -47,11 +55,46 @@ func (c *webCaptureServiceClient) CaptureElement(ctx context.Context, in *Captur
 	return out, nil
 }
 
+func (c *webCaptureServiceClient) RenderDocument(ctx context.Context, in *RenderDocumentRequest, opts ...grpc.CallOption) (*RenderDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderDocumentResponse)
+	err := c.cc.Invoke(ctx, WebCaptureService_RenderDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webCaptureServiceClient) ExtractText(ctx context.Context, in *ExtractTextRequest, opts ...grpc.CallOption) (*ExtractTextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtractTextResponse)
+	err := c.cc.Invoke(ctx, WebCaptureService_ExtractText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webCaptureServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, WebCaptureService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WebCaptureServiceServer is the server API for WebCaptureService service.
 // All implementations must embed UnimplementedWebCaptureServiceServer
 // for forward compatibility.
 type WebCaptureServiceServer interface {
 	CaptureElement(context.Context, *CaptureElementRequest) (*CaptureElementResponse, error)
+	RenderDocument(context.Context, *RenderDocumentRequest) (*RenderDocumentResponse, error)
+	ExtractText(context.Context, *ExtractTextRequest) (*ExtractTextResponse, error)
+	// GetCapabilities lists the optional features the service implements. Services predating a
+	// feature ignore its request fields, so clients check here before relying on them.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
 	mustEmbedUnimplementedWebCaptureServiceServer()
 }
 
@@ -65,6 +108,15 @@ type UnimplementedWebCaptureServiceServer struct{}
 func (UnimplementedWebCaptureServiceServer) CaptureElement(context.Context, *CaptureElementRequest) (*CaptureElementResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CaptureElement not implemented")
 }
+func (UnimplementedWebCaptureServiceServer) RenderDocument(context.Context, *RenderDocumentRequest) (*RenderDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderDocument not implemented")
+}
+func (UnimplementedWebCaptureServiceServer) ExtractText(context.Context, *ExtractTextRequest) (*ExtractTextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractText not implemented")
+}
+func (UnimplementedWebCaptureServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedWebCaptureServiceServer) mustEmbedUnimplementedWebCaptureServiceServer() {}
 func (UnimplementedWebCaptureServiceServer) testEmbeddedByValue()                           {}
 
@@ -104,6 +156,60 @@ func _WebCaptureService_CaptureElement_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WebCaptureService_RenderDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebCaptureServiceServer).RenderDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebCaptureService_RenderDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebCaptureServiceServer).RenderDocument(ctx, req.(*RenderDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebCaptureService_ExtractText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebCaptureServiceServer).ExtractText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebCaptureService_ExtractText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebCaptureServiceServer).ExtractText(ctx, req.(*ExtractTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebCaptureService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebCaptureServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebCaptureService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebCaptureServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WebCaptureService_ServiceDesc is the grpc.ServiceDesc for WebCaptureService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +221,18 @@ var WebCaptureService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CaptureElement",
 			Handler:    _WebCaptureService_CaptureElement_Handler,
 		},
+		{
+			MethodName: "RenderDocument",
+			Handler:    _WebCaptureService_RenderDocument_Handler,
+		},
+		{
+			MethodName: "ExtractText",
+			Handler:    _WebCaptureService_ExtractText_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _WebCaptureService_GetCapabilities_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "web_capture/v1/web_capture.proto",
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Alignment selects how a subscription's first delivery is placed.
+type Alignment string
+
+const (
+	// AlignToWallClock delivers at the next occurrence of the subscription's Time. It is the
+	// default alignment.
+	AlignToWallClock Alignment = "wall_clock"
+	// AlignToCreation delivers StartDelay after the subscription is added and repeats from that
+	// instant, replacing Time with the time of day of the first delivery.
+	AlignToCreation Alignment = "creation"
+)
+
+// ErrUnsupportedAlignment is returned when an alignment name is not recognised.
+var ErrUnsupportedAlignment = errors.New("unsupported alignment")
+
+// ParseAlignment converts user input into an Alignment, defaulting to wall clock when value is
+// empty.
+func ParseAlignment(value string) (Alignment, error) {
+	switch alignment := Alignment(strings.ToLower(strings.TrimSpace(value))); alignment {
+	case "":
+		return AlignToWallClock, nil
+	case AlignToWallClock, AlignToCreation:
+		return alignment, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnsupportedAlignment, value)
+	}
+}
+
+// OrDefault returns a, or AlignToWallClock when a is unset.
+func (a Alignment) OrDefault() Alignment {
+	if a == "" {
+		return AlignToWallClock
+	}
+	return a
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FullPageSelector is the selector users give to capture the whole page. It is stored as an empty
+// ElementSelector.
+const FullPageSelector = "full-page"
+
+// ParseSelector trims a user-supplied selector and maps FullPageSelector to the empty selector.
+func ParseSelector(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, FullPageSelector) {
+		return ""
+	}
+	return value
+}
+
+// CaptureRequest describes the page element to render for a forecast snapshot.
+type CaptureRequest struct {
+	URL string
+	// ElementSelector is the CSS selector of the element captured. Empty captures the whole page.
+	ElementSelector string
+	// MatchIndex selects which element matching ElementSelector is captured, counting from zero.
+	MatchIndex int
+	// Region, when set, is captured instead of ElementSelector.
+	Region Region
+	// Viewport sizes the browser window. Zero uses the capture service's defaults.
+	Viewport Viewport
+	Format   Format
+	// Quality is the encoding quality (1-100) for lossy formats. Zero uses DefaultQuality.
+	Quality int
+	// Background, a "#rrggbb" color, fills transparent areas of the capture. Empty keeps them.
+	Background string
+	// Language is a BCP-47 tag sent as Accept-Language. Empty uses the site's default.
+	Language string
+	// Timezone is the IANA zone the page is rendered in. Empty uses the capture service's zone.
+	Timezone string
+	// Framed embeds the capture in the operator's forecast template before delivery.
+	Framed bool
+}
+
+// FullPage reports whether r captures the whole page rather than an element or region.
+func (r CaptureRequest) FullPage() bool {
+	return r.ElementSelector == "" && r.Region.IsZero()
+}
+
+// Features returns the optional capture service features needed to honour r, in the order they
+// are declared. PDF output and backgrounds are produced locally and need none.
+func (r CaptureRequest) Features() []CaptureFeature {
+	var features []CaptureFeature
+	if r.Language != "" {
+		features = append(features, CaptureFeatureHeaders)
+	}
+	if r.Timezone != "" {
+		features = append(features, CaptureFeatureTimezone)
+	}
+	if !r.Region.IsZero() {
+		features = append(features, CaptureFeatureClip)
+	}
+	if r.MatchIndex != 0 {
+		features = append(features, CaptureFeatureMatchIndex)
+	}
+	lossy := r.Format == FormatJPEG || r.Format == FormatWebP
+	if r.Quality != 0 && lossy && r.Background == "" {
+		features = append(features, CaptureFeatureQuality)
+	}
+	if r.FullPage() {
+		features = append(features, CaptureFeatureFullPage)
+	}
+	if r.Viewport.Width != 0 || r.Viewport.Height != 0 {
+		features = append(features, CaptureFeatureViewport)
+	}
+	if r.Viewport.Scale != 0 {
+		features = append(features, CaptureFeatureScaleFactor)
+	}
+	if r.Framed {
+		features = append(features, CaptureFeatureRenderDocument)
+	}
+	return features
+}
+
+// CaptureFeature is an optional feature of the capture service. Services predating a feature
+// ignore the options that need it instead of rejecting them.
+type CaptureFeature int
+
+const (
+	// CaptureFeatureHeaders sends extra HTTP headers, used for Language.
+	CaptureFeatureHeaders CaptureFeature = iota + 1
+	// CaptureFeatureTimezone renders the page in Timezone.
+	CaptureFeatureTimezone
+	// CaptureFeatureClip captures Region.
+	CaptureFeatureClip
+	// CaptureFeatureMatchIndex captures a later element matching the selector.
+	CaptureFeatureMatchIndex
+	// CaptureFeatureQuality encodes lossy formats at Quality.
+	CaptureFeatureQuality
+	// CaptureFeatureFullPage captures the whole page when there is no selector.
+	CaptureFeatureFullPage
+	// CaptureFeatureViewport sizes the browser window.
+	CaptureFeatureViewport
+	// CaptureFeatureScaleFactor sets the device scale factor.
+	CaptureFeatureScaleFactor
+	// CaptureFeatureRenderDocument renders HTML documents, used for Framed captures.
+	CaptureFeatureRenderDocument
+	// CaptureFeatureTextExtraction extracts page text, used for keyword filters.
+	CaptureFeatureTextExtraction
+)
+
+// captureFeatureOptions names the subscription option that needs each feature.
+var captureFeatureOptions = map[CaptureFeature]string{
+	CaptureFeatureHeaders:        "language",
+	CaptureFeatureTimezone:       "timezone",
+	CaptureFeatureClip:           "region",
+	CaptureFeatureMatchIndex:     "index",
+	CaptureFeatureQuality:        "quality",
+	CaptureFeatureFullPage:       "full-page",
+	CaptureFeatureViewport:       "viewport",
+	CaptureFeatureScaleFactor:    "viewport scale",
+	CaptureFeatureRenderDocument: "framed",
+	CaptureFeatureTextExtraction: "keywords",
+}
+
+// String returns the name of the subscription option that needs f.
+func (f CaptureFeature) String() string {
+	if option, ok := captureFeatureOptions[f]; ok {
+		return option
+	}
+	return fmt.Sprintf("CaptureFeature(%d)", int(f))
+}
+
+// CaptureFeatures is the set of optional features a capture service supports.
+type CaptureFeatures map[CaptureFeature]bool
+
+// Check returns an *UnsupportedCaptureError listing the features of required missing from f, or
+// nil when f has them all.
+func (f CaptureFeatures) Check(required []CaptureFeature) error {
+	var missing []CaptureFeature
+	for _, feature := range required {
+		if !f[feature] {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &UnsupportedCaptureError{Features: missing}
+}
+
+// UnsupportedCaptureError is returned for captures whose options the capture service would
+// silently ignore.
+type UnsupportedCaptureError struct {
+	Features []CaptureFeature
+}
+
+func (e *UnsupportedCaptureError) Error() string {
+	return "capture service does not support " + e.Options()
+}
+
+// Options returns the names of the options needing the unsupported features, comma-separated.
+func (e *UnsupportedCaptureError) Options() string {
+	options := make([]string, len(e.Features))
+	for i, feature := range e.Features {
+		options[i] = feature.String()
+	}
+	return strings.Join(options, ", ")
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCaptureRequestFeatures(t *testing.T) {
+	const selector = "#forecast"
+
+	tests := []struct {
+		name string
+		req  CaptureRequest
+		want []CaptureFeature
+	}{
+		{
+			name: "plain selector capture",
+			req:  CaptureRequest{ElementSelector: selector, Format: FormatPNG},
+		},
+		{
+			name: "pdf and background are produced locally",
+			req:  CaptureRequest{ElementSelector: selector, Format: FormatPDF, Background: "#ffffff"},
+		},
+		{
+			name: "quality of a lossy format",
+			req:  CaptureRequest{ElementSelector: selector, Format: FormatJPEG, Quality: 70},
+			want: []CaptureFeature{CaptureFeatureQuality},
+		},
+		{
+			name: "quality of a flattened jpeg is applied locally",
+			req: CaptureRequest{
+				ElementSelector: selector,
+				Format:          FormatJPEG,
+				Quality:         70,
+				Background:      "#ffffff",
+			},
+		},
+		{
+			name: "quality of png is ignored",
+			req:  CaptureRequest{ElementSelector: selector, Format: FormatPNG, Quality: 70},
+		},
+		{
+			name: "full page",
+			req:  CaptureRequest{},
+			want: []CaptureFeature{CaptureFeatureFullPage},
+		},
+		{
+			name: "region",
+			req:  CaptureRequest{Region: Region{Width: 800, Height: 600}},
+			want: []CaptureFeature{CaptureFeatureClip},
+		},
+		{
+			name: "every option",
+			req: CaptureRequest{
+				ElementSelector: selector,
+				MatchIndex:      2,
+				Viewport:        Viewport{Width: 1280, Height: 800, Scale: 2},
+				Format:          FormatWebP,
+				Quality:         80,
+				Language:        "ja",
+				Timezone:        "Asia/Tokyo",
+				Framed:          true,
+			},
+			want: []CaptureFeature{
+				CaptureFeatureHeaders,
+				CaptureFeatureTimezone,
+				CaptureFeatureMatchIndex,
+				CaptureFeatureQuality,
+				CaptureFeatureViewport,
+				CaptureFeatureScaleFactor,
+				CaptureFeatureRenderDocument,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Features(); !slices.Equal(got, tt.want) {
+				t.Errorf("Features() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCaptureFeaturesIncludesKeywords(t *testing.T) {
+	sub := Subscription{ElementSelector: "#forecast", Keywords: []string{"rain"}}
+
+	want := []CaptureFeature{CaptureFeatureTextExtraction}
+	if got := sub.CaptureFeatures(); !slices.Equal(got, want) {
+		t.Errorf("CaptureFeatures() = %v, want %v", got, want)
+	}
+}
+
+func TestCaptureFeaturesCheck(t *testing.T) {
+	features := CaptureFeatures{CaptureFeatureHeaders: true, CaptureFeatureClip: true}
+
+	if err := features.Check([]CaptureFeature{CaptureFeatureClip}); err != nil {
+		t.Errorf("Check(supported) = %v, want nil", err)
+	}
+
+	err := features.Check([]CaptureFeature{
+		CaptureFeatureHeaders,
+		CaptureFeatureTimezone,
+		CaptureFeatureTextExtraction,
+	})
+	var unsupported *UnsupportedCaptureError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Check(unsupported) = %v, want *UnsupportedCaptureError", err)
+	}
+	if got, want := unsupported.Options(), "timezone, keywords"; got != want {
+		t.Errorf("Options() = %q, want %q", got, want)
+	}
+
+	if err := (CaptureFeatures{}).Check(nil); err != nil {
+		t.Errorf("Check(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidColor is returned when a color is not a hex value such as "#1e2a38" or "#fff".
+var ErrInvalidColor = errors.New("invalid hex color")
+
+// ParseHexColor normalises "#rrggbb", "rrggbb", "#rgb" or "rgb" to lower-case "#rrggbb". An empty
+// value yields an empty color.
+func ParseHexColor(value string) (string, error) {
+	trimmed := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "#"))
+	if trimmed == "" {
+		return "", nil
+	}
+
+	for _, r := range trimmed {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", fmt.Errorf("%w %q", ErrInvalidColor, value)
+		}
+	}
+
+	switch len(trimmed) {
+	case 3:
+		return fmt.Sprintf(
+			"#%c%c%c%c%c%c",
+			trimmed[0], trimmed[0], trimmed[1], trimmed[1], trimmed[2], trimmed[2],
+		), nil
+	case 6:
+		return "#" + trimmed, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrInvalidColor, value)
+	}
+}
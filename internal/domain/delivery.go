@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Delivery is a rendered forecast ready to be dispatched to a channel.
+type Delivery struct {
+	ChannelID string
+	// Images holds one capture per attachment, in display order.
+	Images  [][]byte
+	Format  Format
+	Message string
+	// SourceURL is the page the images were captured from.
+	SourceURL string
+	// WebhookURL, when set, posts the delivery through this Discord webhook instead of as the bot.
+	WebhookURL string
+	// ReplyToMessageID, when set, posts the delivery as a reply to that message in the channel.
+	ReplyToMessageID string
+	// FallbackCapturedAt is set when Images are an earlier capture reused because a fresh capture
+	// failed, and records when they were captured.
+	FallbackCapturedAt time.Time
+}
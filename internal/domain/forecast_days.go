@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxForecastDays bounds how many days one delivery may capture; Discord allows at most ten
+// attachments per message.
+const MaxForecastDays = 10
+
+// MaxForecastDayOffset is the furthest day ahead a subscription may capture.
+const MaxForecastDayOffset = 13
+
+// ErrInvalidForecastDays is returned when day offsets are malformed, duplicated or out of range.
+var ErrInvalidForecastDays = errors.New("invalid forecast day offsets")
+
+// ParseForecastDays parses a comma-separated list of day offsets such as "0,1,2".
+// An empty value yields no offsets, meaning a single capture of the configured URL.
+func ParseForecastDays(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var offsets []int
+	for _, field := range strings.Split(value, ",") {
+		offset, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidForecastDays, field)
+		}
+		offsets = append(offsets, offset)
+	}
+
+	if err := validateForecastDays(offsets); err != nil {
+		return nil, err
+	}
+
+	return offsets, nil
+}
+
+// FormatForecastDays renders offsets in the form accepted by ParseForecastDays.
+func FormatForecastDays(offsets []int) string {
+	fields := make([]string, 0, len(offsets))
+	for _, offset := range offsets {
+		fields = append(fields, strconv.Itoa(offset))
+	}
+
+	return strings.Join(fields, ",")
+}
+
+func validateForecastDays(offsets []int) error {
+	if len(offsets) > MaxForecastDays {
+		return fmt.Errorf("%w: at most %d days may be captured", ErrInvalidForecastDays, MaxForecastDays)
+	}
+
+	for index, offset := range offsets {
+		if offset < 0 || offset > MaxForecastDayOffset {
+			return fmt.Errorf(
+				"%w: %d is outside 0-%d",
+				ErrInvalidForecastDays,
+				offset,
+				MaxForecastDayOffset,
+			)
+		}
+		if slices.Contains(offsets[:index], offset) {
+			return fmt.Errorf("%w: %d is listed twice", ErrInvalidForecastDays, offset)
+		}
+	}
+
+	return nil
+}
+
+// expandDayPlaceholders substitutes {date} (YYYY-MM-DD) and {offset} for the given day.
+func expandDayPlaceholders(template string, day time.Time, offset int) string {
+	return strings.NewReplacer(
+		"{date}", day.Format(time.DateOnly),
+		"{offset}", strconv.Itoa(offset),
+	).Replace(template)
+}
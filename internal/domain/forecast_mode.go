@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ForecastMode selects how a subscription's target page is resolved at delivery time.
+type ForecastMode string
+
+const (
+	// ForecastModeFixed captures the subscription's own URL exactly as configured. It is the
+	// default mode.
+	ForecastModeFixed ForecastMode = "fixed"
+	// ForecastModeLatest captures the operator's current "latest forecast" page at each delivery,
+	// so the subscription follows configuration changes instead of pinning a URL.
+	ForecastModeLatest ForecastMode = "latest"
+)
+
+// ErrUnsupportedForecastMode is returned when a forecast mode name is not recognised.
+var ErrUnsupportedForecastMode = errors.New("unsupported forecast mode")
+
+// ParseForecastMode converts user input into a ForecastMode, defaulting to fixed when value is
+// empty.
+func ParseForecastMode(value string) (ForecastMode, error) {
+	switch mode := ForecastMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ForecastModeFixed, nil
+	case ForecastModeFixed, ForecastModeLatest:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnsupportedForecastMode, value)
+	}
+}
+
+// OrDefault returns m, or ForecastModeFixed when m is unset.
+func (m ForecastMode) OrDefault() ForecastMode {
+	if m == "" {
+		return ForecastModeFixed
+	}
+	return m
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format identifies the file type a captured forecast is delivered as.
+type Format string
+
+const (
+	// FormatPNG delivers the forecast as a PNG image. It is the default format.
+	FormatPNG Format = "png"
+	// FormatJPEG delivers the forecast as a JPEG image, much smaller than PNG for large maps.
+	FormatJPEG Format = "jpeg"
+	// FormatWebP delivers the forecast as a WebP image.
+	FormatWebP Format = "webp"
+	// FormatPDF delivers the forecast as a single-page PDF document for archival.
+	FormatPDF Format = "pdf"
+)
+
+// DefaultQuality is the encoding quality used for lossy formats when none is chosen.
+const DefaultQuality = 90
+
+// ErrInvalidQuality is returned when an encoding quality is outside 1-100.
+var ErrInvalidQuality = errors.New("quality must be between 1 and 100")
+
+// ErrUnsupportedFormat is returned when a format name is not recognised.
+var ErrUnsupportedFormat = errors.New("unsupported forecast format")
+
+// ErrFormatNotFlattenable is returned when a background is combined with a format that cannot be
+// re-encoded locally after flattening.
+var ErrFormatNotFlattenable = errors.New("format cannot be flattened onto a background")
+
+// ParseFormat converts user input into a Format, defaulting to PNG when value is empty.
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return FormatPNG, nil
+	case "jpg":
+		return FormatJPEG, nil
+	case FormatPNG, FormatJPEG, FormatWebP, FormatPDF:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnsupportedFormat, value)
+	}
+}
+
+// ParseFormatWithQuality converts input such as "jpeg" or "jpeg:80" into a Format and the
+// encoding quality given after the colon, which is zero, the default, when there is none.
+func ParseFormatWithQuality(value string) (Format, int, error) {
+	name, qualityText, hasQuality := strings.Cut(value, ":")
+	format, err := ParseFormat(name)
+	if err != nil {
+		return "", 0, err
+	}
+	if !hasQuality {
+		return format, 0, nil
+	}
+
+	quality, err := strconv.Atoi(strings.TrimSpace(qualityText))
+	if err != nil || quality < 1 {
+		return "", 0, ErrInvalidQuality
+	}
+	if err := validateQuality(quality); err != nil {
+		return "", 0, err
+	}
+	return format, quality, nil
+}
+
+// OrDefault returns f, or FormatPNG when f is unset.
+func (f Format) OrDefault() Format {
+	if f == "" {
+		return FormatPNG
+	}
+	return f
+}
+
+// ContentType returns the MIME type of files in this format.
+func (f Format) ContentType() string {
+	switch f.OrDefault() {
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatWebP:
+		return "image/webp"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "image/png"
+	}
+}
+
+// FileName returns the attachment name used when delivering a forecast in this format.
+func (f Format) FileName() string {
+	return "weather_forecast." + string(f.OrDefault())
+}
+
+// QualityOrDefault returns quality, or DefaultQuality when quality is unset.
+func QualityOrDefault(quality int) int {
+	if quality == 0 {
+		return DefaultQuality
+	}
+	return quality
+}
+
+// validateQuality accepts zero (the default) or a quality between 1 and 100.
+func validateQuality(quality int) error {
+	if quality < 0 || quality > 100 {
+		return ErrInvalidQuality
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxKeywords bounds how many keywords one subscription may filter on.
+const MaxKeywords = 10
+
+// maxKeywordLength bounds the characters in a single keyword.
+const maxKeywordLength = 32
+
+// ErrInvalidKeywords is returned when keywords are empty, duplicated, too long or too many, or
+// are combined with a region capture, which has no element text to match.
+var ErrInvalidKeywords = errors.New("invalid keywords")
+
+// ErrTextExtractionUnsupported is returned by capture services that cannot extract page text.
+// Keyword filters are skipped when it is reported.
+var ErrTextExtractionUnsupported = errors.New("capture service does not support text extraction")
+
+// ParseKeywords parses a comma-separated list of keywords such as "rain, storm". Keywords are
+// matched case-insensitively and stored in lower case. An empty value yields no keywords.
+func ParseKeywords(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var keywords []string
+	for _, field := range strings.Split(value, ",") {
+		keywords = append(keywords, strings.ToLower(strings.TrimSpace(field)))
+	}
+
+	if err := validateKeywords(keywords); err != nil {
+		return nil, err
+	}
+
+	return keywords, nil
+}
+
+// FormatKeywords renders keywords in the form accepted by ParseKeywords.
+func FormatKeywords(keywords []string) string {
+	return strings.Join(keywords, ",")
+}
+
+// MatchesKeywords reports whether text contains any of keywords, ignoring case.
+func MatchesKeywords(text string, keywords []string) bool {
+	text = strings.ToLower(text)
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateKeywords(keywords []string) error {
+	if len(keywords) > MaxKeywords {
+		return fmt.Errorf("%w: at most %d keywords are allowed", ErrInvalidKeywords, MaxKeywords)
+	}
+
+	for index, keyword := range keywords {
+		if keyword == "" || utf8.RuneCountInString(keyword) > maxKeywordLength {
+			return fmt.Errorf(
+				"%w: keywords must be 1-%d characters",
+				ErrInvalidKeywords,
+				maxKeywordLength,
+			)
+		}
+		if keyword != strings.ToLower(keyword) || strings.Contains(keyword, ",") {
+			return fmt.Errorf("%w: %q must be lower case without commas", ErrInvalidKeywords, keyword)
+		}
+		if slices.Contains(keywords[:index], keyword) {
+			return fmt.Errorf("%w: %q is listed twice", ErrInvalidKeywords, keyword)
+		}
+	}
+
+	return nil
+}
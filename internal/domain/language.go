@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Language describes a BCP-47 tag a forecast page can be rendered in.
+type Language struct {
+	Tag  string
+	Name string
+}
+
+// SupportedLanguages lists the languages subscriptions may request via Accept-Language.
+var SupportedLanguages = []Language{
+	{Tag: "en", Name: "English"},
+	{Tag: "en-US", Name: "English (United States)"},
+	{Tag: "en-GB", Name: "English (United Kingdom)"},
+	{Tag: "ja", Name: "Japanese"},
+	{Tag: "ja-JP", Name: "Japanese (Japan)"},
+	{Tag: "ko", Name: "Korean"},
+	{Tag: "zh-CN", Name: "Chinese (Simplified)"},
+	{Tag: "zh-TW", Name: "Chinese (Traditional)"},
+	{Tag: "fr", Name: "French"},
+	{Tag: "de", Name: "German"},
+	{Tag: "es", Name: "Spanish"},
+}
+
+// ErrUnsupportedLanguage is returned when a language tag is not in SupportedLanguages.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// ParseLanguage returns the canonical tag for value. An empty value yields an empty tag, meaning
+// the site's default language.
+func ParseLanguage(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", nil
+	}
+
+	for _, language := range SupportedLanguages {
+		if strings.EqualFold(language.Tag, trimmed) {
+			return language.Tag, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q", ErrUnsupportedLanguage, value)
+}
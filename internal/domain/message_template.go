@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxMessageLength bounds the characters of a subscription's message after its placeholders are
+// expanded. Discord accepts 2000 characters per message; the rest is kept for the notes appended
+// to a delivery, e.g. when a stale capture is posted instead of a failed one.
+const MaxMessageLength = 1800
+
+// ErrInvalidMessage is returned when a message could expand to more than MaxMessageLength
+// characters.
+var ErrInvalidMessage = errors.New("message is too long")
+
+// longestWeekday is a Wednesday, whose name is the longest placeholder value {weekday} takes.
+var longestWeekday = time.Date(2000, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+// ValidateMessage rejects message when, once expanded for sourceURL, it could be longer than
+// MaxMessageLength characters on some day.
+func ValidateMessage(message string, sourceURL string) error {
+	if utf8.RuneCountInString(ExpandMessage(message, longestWeekday, sourceURL)) > MaxMessageLength {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// ExpandMessage substitutes the delivery placeholders in message: {date} (YYYY-MM-DD), {time}
+// (HH:MM), {weekday} (e.g. Monday), {url} (the captured page) and {source} (its host). at should
+// already be in the subscription's timezone. Messages without placeholders are returned as is.
+func ExpandMessage(message string, at time.Time, sourceURL string) string {
+	if !strings.Contains(message, "{") {
+		return message
+	}
+
+	source := sourceURL
+	if parsed, err := url.Parse(sourceURL); err == nil && parsed.Host != "" {
+		source = parsed.Host
+	}
+
+	return strings.NewReplacer(
+		"{date}", at.Format(time.DateOnly),
+		"{time}", at.Format("15:04"),
+		"{weekday}", at.Weekday().String(),
+		"{url}", sourceURL,
+		"{source}", source,
+	).Replace(message)
+}
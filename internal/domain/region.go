@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxRegionSize bounds the width and height of a capture region in CSS pixels.
+const MaxRegionSize = 4096
+
+// ErrInvalidRegion is returned when a capture region is malformed or out of bounds.
+var ErrInvalidRegion = errors.New("invalid capture region")
+
+// Region is a rectangle of the rendered page, in CSS pixels from its top-left corner, captured
+// instead of an element.
+type Region struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// ParseRegion parses "x,y,width,height", e.g. "0,120,800,600". An empty value yields the zero
+// Region, meaning the capture is selector based.
+func ParseRegion(value string) (Region, error) {
+	if strings.TrimSpace(value) == "" {
+		return Region{}, nil
+	}
+
+	fields := strings.Split(value, ",")
+	if len(fields) != 4 {
+		return Region{}, fmt.Errorf("%w: expected x,y,width,height", ErrInvalidRegion)
+	}
+
+	var numbers [4]int
+	for index, field := range fields {
+		number, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return Region{}, fmt.Errorf("%w: %q is not a number", ErrInvalidRegion, field)
+		}
+		numbers[index] = number
+	}
+
+	region := Region{X: numbers[0], Y: numbers[1], Width: numbers[2], Height: numbers[3]}
+	if err := region.Validate(); err != nil {
+		return Region{}, err
+	}
+
+	return region, nil
+}
+
+// IsZero reports whether r is unset.
+func (r Region) IsZero() bool {
+	return r == Region{}
+}
+
+// String formats r as "x,y,width,height", or "" when r is unset.
+func (r Region) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d,%d,%d,%d", r.X, r.Y, r.Width, r.Height)
+}
+
+// Validate reports whether r lies within the page and has a usable size. The zero Region is valid.
+func (r Region) Validate() error {
+	if r.IsZero() {
+		return nil
+	}
+	if r.X < 0 || r.Y < 0 {
+		return fmt.Errorf("%w: offsets must not be negative", ErrInvalidRegion)
+	}
+	if r.Width <= 0 || r.Height <= 0 || r.Width > MaxRegionSize || r.Height > MaxRegionSize {
+		return fmt.Errorf(
+			"%w: width and height must be between 1 and %d",
+			ErrInvalidRegion,
+			MaxRegionSize,
+		)
+	}
+	return nil
+}
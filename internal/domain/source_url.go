@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidURL is returned when a forecast source is not an absolute http or https URL.
+var ErrInvalidURL = errors.New("invalid forecast URL")
+
+// ParseSourceURL checks that value is an absolute http or https URL with a host and returns it
+// with surrounding whitespace removed. The URL is otherwise returned as written, since
+// re-encoding it would escape placeholders such as {date}.
+func ParseSourceURL(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("%w %q", ErrInvalidURL, value)
+	}
+
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("%w %q: scheme must be http or https", ErrInvalidURL, value)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("%w %q: missing host", ErrInvalidURL, value)
+	}
+
+	return trimmed, nil
+}
@@ -1,13 +1,299 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+)
+
+// MinimumInterval is the shortest cadence an interval-based subscription may use.
+const MinimumInterval = time.Hour
+
+// ErrIntervalTooShort is returned when a subscription repeats more often than MinimumInterval.
+var ErrIntervalTooShort = errors.New("subscription interval is shorter than the minimum allowed")
+
+// ErrInvalidStartDelay is returned when a subscription's start delay is negative.
+var ErrInvalidStartDelay = errors.New("start delay must not be negative")
+
+// ErrInvalidMatchIndex is returned when a subscription's selector match index is negative.
+var ErrInvalidMatchIndex = errors.New("match index must not be negative")
+
+// MaxExtraChannels bounds how many additional channels one subscription may post to.
+const MaxExtraChannels = 5
+
+// ErrInvalidExtraChannels is returned when additional channels repeat, include the primary
+// channel or exceed MaxExtraChannels.
+var ErrInvalidExtraChannels = errors.New("invalid additional channels")
+
+// ErrSubscriptionNotFound is returned when no subscription matches a lookup.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// ErrInvalidMaxStaleness is returned when a subscription's fallback age limit is negative.
+var ErrInvalidMaxStaleness = errors.New("maximum staleness must not be negative")
+
+// MaxCaptureTimeout bounds a subscription's own capture timeout, so a page that never finishes
+// loading cannot hold a capture for long.
+const MaxCaptureTimeout = 5 * time.Minute
+
+// ErrInvalidCaptureTimeout is returned when a subscription's capture timeout is negative or longer
+// than MaxCaptureTimeout.
+var ErrInvalidCaptureTimeout = errors.New("capture timeout is out of range")
+
+// MaxLabelLength bounds the characters in a subscription label.
+const MaxLabelLength = 80
+
+// ErrInvalidLabel is returned when a subscription label is longer than MaxLabelLength.
+var ErrInvalidLabel = errors.New("subscription label is too long")
 
 // Subscription represents a daily forecast delivery configuration for a Discord channel.
 type Subscription struct {
-	ChannelID       string
-	GuildID         string
+	// ID identifies the persisted subscription. Zero until the subscription has been stored.
+	ID        uint
+	ChannelID string
+	GuildID   string
+	// ExtraChannelIDs receive the same capture as ChannelID, posted separately to each.
+	ExtraChannelIDs []string
+	// Label is a human-readable name such as "Kanto morning map". Empty uses a label derived
+	// from URL and Time; see DisplayLabel.
+	Label string
+	// CreatedByUserID is the Discord user who owns the subscription. Empty for subscriptions
+	// created before ownership was recorded.
+	CreatedByUserID string
+	// UpdatedByUserID is the Discord user who last edited the subscription. Empty until it is
+	// first edited.
+	UpdatedByUserID string
 	Time            time.Time
 	URL             string
 	ElementSelector string
 	Message         string
+	// ReplyToMessageID, when set, makes every delivery a reply to this message in ChannelID so
+	// the channel's forecasts form one thread of replies.
+	ReplyToMessageID string
+	// MatchIndex selects which element matching ElementSelector is captured. Zero is the first.
+	MatchIndex int
+	// Region, when set, is captured instead of ElementSelector.
+	Region Region
+	// Viewport sizes the browser window the page is rendered in. Zero uses the capture service's
+	// defaults.
+	Viewport Viewport
+	// Alignment places the first delivery. Empty uses the manager's default.
+	Alignment Alignment
+	// StartDelay postpones the first delivery of a creation-aligned subscription. It is only
+	// consulted when the subscription is added.
+	StartDelay time.Duration
+	// Days limits deliveries to these days of the week, in the subscription's timezone. Zero means
+	// every day.
+	Days Weekdays
+	// EveryN repeats the delivery at this interval, anchored to Time. Zero means daily.
+	EveryN time.Duration
+	// Format selects the delivered file type. Empty means PNG.
+	Format Format
+	// Quality is the encoding quality (1-100) for lossy formats. Zero uses DefaultQuality.
+	Quality int
+	// Background, a "#rrggbb" color, fills transparent areas of every capture so it stays legible
+	// on any Discord theme. Empty leaves transparency as captured.
+	Background string
+	// Language is the BCP-47 tag requested from the source site. Empty uses the site's default.
+	Language string
+	// Timezone is the IANA zone Time is given in and the page's own times are rendered in. Empty
+	// schedules in UTC and renders in the capture service's zone.
+	Timezone string
+	// ForecastDays lists day offsets (0 is today) captured into one post. {date} and {offset} in
+	// URL and ElementSelector are expanded per day. Empty captures URL once as configured.
+	ForecastDays []int
+	// Framed wraps each capture in the operator's forecast template (header, timestamp).
+	Framed bool
+	// Mode selects whether URL is captured as configured or replaced by the latest forecast page
+	// at delivery time. Empty means fixed.
+	Mode ForecastMode
+	// MaxStaleness bounds how old a previous capture may be when it is posted in place of a
+	// failed capture. Zero uses the operator's default.
+	MaxStaleness time.Duration
+	// CaptureTimeout bounds each capture of the subscription, e.g. longer for a heavy page. Zero
+	// uses the operator's default.
+	CaptureTimeout time.Duration
+	// ConfirmFirstDelivery asks for a direct message to CreatedByUserID after the first
+	// successful delivery. It is cleared once the confirmation has been attempted.
+	ConfirmFirstDelivery bool
+	// ErrorNotifyChannelID, when set, receives a short notice whenever a delivery fails, in
+	// addition to the operator's global error handling.
+	ErrorNotifyChannelID string
+	// WebhookURL, when set, is a Discord webhook of ChannelID that posts the deliveries in place
+	// of the bot, under the webhook's name and avatar.
+	WebhookURL string
+	// Keywords, when set, limit deliveries to times when the captured element's text contains at
+	// least one of them (lower case). Ignored when the capture service cannot extract text.
+	Keywords []string
+	// Paused suspends deliveries without removing the subscription. Its schedule keeps running,
+	// so resuming it picks up at the next regular delivery.
+	Paused bool
+	// NextRunAt is when the subscription is next delivered, as last recorded by the scheduler.
+	// Zero until the subscription has been scheduled.
+	NextRunAt time.Time
+}
+
+// CaptureRequest returns the capture parameters used for scheduled deliveries of s.
+func (s Subscription) CaptureRequest() CaptureRequest {
+	return CaptureRequest{
+		URL:             s.URL,
+		ElementSelector: s.ElementSelector,
+		MatchIndex:      s.MatchIndex,
+		Region:          s.Region,
+		Viewport:        s.Viewport,
+		Format:          s.Format,
+		Quality:         s.Quality,
+		Background:      s.Background,
+		Language:        s.Language,
+		Timezone:        s.Timezone,
+		Framed:          s.Framed,
+	}
+}
+
+// CaptureFeatures returns the optional capture service features needed to deliver s as
+// configured, including text extraction for keyword filters.
+func (s Subscription) CaptureFeatures() []CaptureFeature {
+	features := s.CaptureRequest().Features()
+	if len(s.Keywords) > 0 {
+		features = append(features, CaptureFeatureTextExtraction)
+	}
+	return features
+}
+
+// CaptureRequests returns one capture per configured forecast day, relative to now.
+func (s Subscription) CaptureRequests(now time.Time) []CaptureRequest {
+	if len(s.ForecastDays) == 0 {
+		return []CaptureRequest{s.CaptureRequest()}
+	}
+
+	requests := make([]CaptureRequest, 0, len(s.ForecastDays))
+	for _, offset := range s.ForecastDays {
+		day := now.AddDate(0, 0, offset)
+		req := s.CaptureRequest()
+		req.URL = expandDayPlaceholders(s.URL, day, offset)
+		req.ElementSelector = expandDayPlaceholders(s.ElementSelector, day, offset)
+		requests = append(requests, req)
+	}
+
+	return requests
+}
+
+// Location returns the zone s is scheduled in: Timezone when set, otherwise UTC. Timezone is
+// validated before being stored, so an unknown zone can only come from manual edits and also
+// yields UTC.
+func (s Subscription) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// DisplayLabel returns Label, or a label derived from the URL's host and the delivery time when
+// no label was set.
+func (s Subscription) DisplayLabel() string {
+	if s.Label != "" {
+		return s.Label
+	}
+
+	host := s.URL
+	if parsed, err := url.Parse(s.URL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	if s.Time.IsZero() {
+		return host
+	}
+	return fmt.Sprintf("%s %s", host, s.Time.Format("15:04"))
+}
+
+// TargetChannelIDs returns the primary channel followed by any additional channels.
+func (s Subscription) TargetChannelIDs() []string {
+	return append([]string{s.ChannelID}, s.ExtraChannelIDs...)
+}
+
+// Validate reports whether the subscription's settings are acceptable.
+func (s Subscription) Validate() error {
+	if s.EveryN != 0 && s.EveryN < MinimumInterval {
+		return ErrIntervalTooShort
+	}
+	if err := s.validateExtraChannels(); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(s.Label) > MaxLabelLength {
+		return ErrInvalidLabel
+	}
+	if err := ValidateMessage(s.Message, s.URL); err != nil {
+		return err
+	}
+	if _, err := ParseAlignment(string(s.Alignment)); err != nil {
+		return err
+	}
+	if s.StartDelay < 0 {
+		return ErrInvalidStartDelay
+	}
+	if s.MaxStaleness < 0 {
+		return ErrInvalidMaxStaleness
+	}
+	if s.CaptureTimeout < 0 || s.CaptureTimeout > MaxCaptureTimeout {
+		return ErrInvalidCaptureTimeout
+	}
+	if err := validateQuality(s.Quality); err != nil {
+		return err
+	}
+	if _, err := ParseHexColor(s.Background); err != nil {
+		return err
+	}
+	if s.Background != "" && s.Format == FormatWebP {
+		return fmt.Errorf("%w: %s", ErrFormatNotFlattenable, s.Format)
+	}
+	if s.MatchIndex < 0 {
+		return ErrInvalidMatchIndex
+	}
+	if err := s.Region.Validate(); err != nil {
+		return err
+	}
+	if err := s.Viewport.Validate(); err != nil {
+		return err
+	}
+	if _, err := ParseForecastMode(string(s.Mode)); err != nil {
+		return err
+	}
+	if _, err := ParseLanguage(s.Language); err != nil {
+		return err
+	}
+	if _, err := ParseTimezone(s.Timezone); err != nil {
+		return err
+	}
+	if err := validateForecastDays(s.ForecastDays); err != nil {
+		return err
+	}
+	if err := validateKeywords(s.Keywords); err != nil {
+		return err
+	}
+	if len(s.Keywords) > 0 && !s.Region.IsZero() {
+		return fmt.Errorf("%w: keywords require a selector capture", ErrInvalidKeywords)
+	}
+
+	return nil
+}
+
+func (s Subscription) validateExtraChannels() error {
+	if len(s.ExtraChannelIDs) > MaxExtraChannels {
+		return fmt.Errorf("%w: at most %d are allowed", ErrInvalidExtraChannels, MaxExtraChannels)
+	}
+
+	seen := map[string]struct{}{s.ChannelID: {}}
+	for _, channelID := range s.ExtraChannelIDs {
+		if _, duplicate := seen[channelID]; duplicate || channelID == "" {
+			return fmt.Errorf("%w: %q is repeated or empty", ErrInvalidExtraChannels, channelID)
+		}
+		seen[channelID] = struct{}{}
+	}
+
+	return nil
 }
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTimeOfDay is returned when a delivery time is not HH:MM with an optional UTC offset.
+var ErrInvalidTimeOfDay = errors.New("invalid time of day")
+
+// timeOfDayOffsetLayouts are the accepted spellings of a time with a UTC offset, e.g.
+// "08:00+09:00", "08:00+0900", "08:00+09" and "08:00Z".
+var timeOfDayOffsetLayouts = []string{"15:04Z07:00", "15:04Z0700", "15:04Z07"}
+
+// MaxDeliveryTimes bounds how many delivery times one subscribe request may list.
+const MaxDeliveryTimes = 6
+
+// ParseTimesOfDay parses a comma-separated list of delivery times such as "08:00,18:00" with
+// ParseTimeOfDay. The list is rejected as a whole when any entry is invalid or repeated.
+func ParseTimesOfDay(value string, now time.Time) ([]time.Time, error) {
+	fields := strings.Split(value, ",")
+	if len(fields) > MaxDeliveryTimes {
+		return nil, fmt.Errorf("%w: at most %d times may be given", ErrInvalidTimeOfDay, MaxDeliveryTimes)
+	}
+
+	times := make([]time.Time, 0, len(fields))
+	for _, field := range fields {
+		parsed, err := ParseTimeOfDay(field, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range times {
+			if existing.Equal(parsed) {
+				return nil, fmt.Errorf("%w: %s is listed twice", ErrInvalidTimeOfDay, parsed.Format("15:04"))
+			}
+		}
+		times = append(times, parsed)
+	}
+
+	return times, nil
+}
+
+// ParseTimeOfDay parses a delivery time such as "08:00" or "08:00+09:00".
+//
+// Subscriptions are scheduled in the location of now (the subscription's timezone, or UTC), so a
+// time without an offset is taken as-is while a time with an offset is converted to the
+// equivalent time in that location on now's date. The result carries only the hour and minute,
+// like times parsed with the "15:04" layout.
+func ParseTimeOfDay(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if parsed, err := time.Parse("15:04", value); err == nil {
+		return parsed, nil
+	}
+
+	for _, layout := range timeOfDayOffsetLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		_, offset := parsed.Zone()
+		instant := time.Date(
+			now.Year(),
+			now.Month(),
+			now.Day(),
+			parsed.Hour(),
+			parsed.Minute(),
+			0,
+			0,
+			time.FixedZone("", offset),
+		).In(now.Location())
+
+		return time.Date(0, time.January, 1, instant.Hour(), instant.Minute(), 0, 0, time.UTC), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimeOfDay, value)
+}
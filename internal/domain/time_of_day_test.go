@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		now     time.Time
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "without offset",
+			value: "08:00",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, tokyo),
+			want:  "08:00",
+		},
+		{
+			name:  "without offset surrounded by spaces",
+			value: " 18:30 ",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			want:  "18:30",
+		},
+		{
+			name:  "offset matching the location",
+			value: "08:00+09:00",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, tokyo),
+			want:  "08:00",
+		},
+		{
+			name:  "offset converted to the location",
+			value: "08:00+09:00",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			want:  "23:00",
+		},
+		{
+			name:  "compact offset",
+			value: "08:00+0900",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			want:  "23:00",
+		},
+		{
+			name:  "hour-only offset",
+			value: "08:00-05",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			want:  "13:00",
+		},
+		{
+			name:  "Z suffix",
+			value: "08:00Z",
+			now:   time.Date(2024, time.May, 1, 12, 0, 0, 0, tokyo),
+			want:  "17:00",
+		},
+		{
+			name:  "offset converted using daylight saving time on now's date",
+			value: "12:00Z",
+			now:   time.Date(2024, time.July, 1, 12, 0, 0, 0, newYork),
+			want:  "08:00",
+		},
+		{
+			name:  "offset converted using standard time on now's date",
+			value: "12:00Z",
+			now:   time.Date(2024, time.January, 1, 12, 0, 0, 0, newYork),
+			want:  "07:00",
+		},
+		{
+			name:    "missing minutes",
+			value:   "8",
+			now:     time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name:    "out of range",
+			value:   "25:00",
+			now:     time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name:    "zone name instead of offset",
+			value:   "08:00 JST",
+			now:     time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeOfDay(tt.value, tt.now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTimeOfDay) {
+					t.Fatalf("ParseTimeOfDay(%q) error = %v, want ErrInvalidTimeOfDay", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTimeOfDay(%q): %v", tt.value, err)
+			}
+			if formatted := got.Format("15:04"); formatted != tt.want {
+				t.Errorf("ParseTimeOfDay(%q) = %s, want %s", tt.value, formatted, tt.want)
+			}
+			if got.Year() != 0 || got.Location() != time.UTC {
+				t.Errorf("ParseTimeOfDay(%q) = %v, want a bare time of day", tt.value, got)
+			}
+		})
+	}
+}
+
+func TestParseTimesOfDay(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := ParseTimesOfDay("08:00, 18:00+01:00", now)
+	if err != nil {
+		t.Fatalf("ParseTimesOfDay: %v", err)
+	}
+	if len(got) != 2 || got[0].Format("15:04") != "08:00" || got[1].Format("15:04") != "17:00" {
+		t.Errorf("ParseTimesOfDay = %v, want [08:00 17:00]", got)
+	}
+
+	invalid := []string{
+		"08:00,08:00",
+		"08:00,07:00-01:00",
+		"01:00,02:00,03:00,04:00,05:00,06:00,07:00",
+		"08:00,",
+	}
+	for _, value := range invalid {
+		if _, err := ParseTimesOfDay(value, now); !errors.Is(err, ErrInvalidTimeOfDay) {
+			t.Errorf("ParseTimesOfDay(%q) error = %v, want ErrInvalidTimeOfDay", value, err)
+		}
+	}
+}
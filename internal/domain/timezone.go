@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnknownTimezone is returned when a timezone is not a known IANA zone name.
+var ErrUnknownTimezone = errors.New("unknown timezone")
+
+// ParseTimezone validates an IANA zone name such as "Asia/Tokyo" and returns it as given. An empty
+// value yields an empty name, meaning the capture service's own timezone.
+func ParseTimezone(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", nil
+	}
+
+	// LoadLocation also accepts "Local", which would mean the bot's zone rather than a zone the
+	// capture service can emulate.
+	if strings.EqualFold(trimmed, "local") {
+		return "", fmt.Errorf("%w %q", ErrUnknownTimezone, value)
+	}
+	location, err := time.LoadLocation(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("%w %q", ErrUnknownTimezone, value)
+	}
+
+	return location.String(), nil
+}
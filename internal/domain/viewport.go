@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinViewportSize and MaxViewportSize bound the browser window width and height in CSS pixels.
+const (
+	MinViewportSize = 320
+	MaxViewportSize = 4096
+)
+
+// MinViewportScale and MaxViewportScale bound the device scale factor of a capture.
+const (
+	MinViewportScale = 0.5
+	MaxViewportScale = 4.0
+)
+
+// ErrInvalidViewport is returned when a capture viewport is malformed or out of range.
+var ErrInvalidViewport = errors.New("invalid capture viewport")
+
+// Viewport sizes the browser window a page is rendered in. Zero fields use the capture service's
+// defaults.
+type Viewport struct {
+	Width  int
+	Height int
+	// Scale is the device scale factor; 2 renders every CSS pixel as four image pixels.
+	Scale float64
+}
+
+// ParseViewport parses "widthxheight", optionally followed by "@scale", or "@scale" alone, e.g.
+// "1280x800", "1280x800@2" or "@2". An empty value yields the zero Viewport.
+func ParseViewport(value string) (Viewport, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return Viewport{}, nil
+	}
+
+	var viewport Viewport
+	size, scale, hasScale := strings.Cut(value, "@")
+	if hasScale {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(scale), 64)
+		if err != nil {
+			return Viewport{}, fmt.Errorf("%w: %q is not a scale", ErrInvalidViewport, scale)
+		}
+		viewport.Scale = parsed
+	}
+	if size = strings.TrimSpace(size); size != "" {
+		width, height, ok := strings.Cut(size, "x")
+		if !ok {
+			return Viewport{}, fmt.Errorf("%w: expected widthxheight[@scale]", ErrInvalidViewport)
+		}
+		for _, field := range []struct {
+			value  string
+			target *int
+		}{{width, &viewport.Width}, {height, &viewport.Height}} {
+			number, err := strconv.Atoi(strings.TrimSpace(field.value))
+			if err != nil {
+				return Viewport{}, fmt.Errorf(
+					"%w: %q is not a number",
+					ErrInvalidViewport,
+					field.value,
+				)
+			}
+			*field.target = number
+		}
+	}
+
+	if err := viewport.Validate(); err != nil {
+		return Viewport{}, err
+	}
+
+	return viewport, nil
+}
+
+// IsZero reports whether v is unset.
+func (v Viewport) IsZero() bool {
+	return v == Viewport{}
+}
+
+// String formats v in the form accepted by ParseViewport, or "" when v is unset.
+func (v Viewport) String() string {
+	var builder strings.Builder
+	if v.Width != 0 || v.Height != 0 {
+		fmt.Fprintf(&builder, "%dx%d", v.Width, v.Height)
+	}
+	if v.Scale != 0 {
+		builder.WriteString("@" + strconv.FormatFloat(v.Scale, 'f', -1, 64))
+	}
+	return builder.String()
+}
+
+// Validate reports whether v's size and scale are within range. The zero Viewport is valid.
+func (v Viewport) Validate() error {
+	if v.Width != 0 || v.Height != 0 {
+		if v.Width < MinViewportSize || v.Width > MaxViewportSize ||
+			v.Height < MinViewportSize || v.Height > MaxViewportSize {
+			return fmt.Errorf(
+				"%w: width and height must be between %d and %d",
+				ErrInvalidViewport,
+				MinViewportSize,
+				MaxViewportSize,
+			)
+		}
+	}
+	if v.Scale != 0 && (v.Scale < MinViewportScale || v.Scale > MaxViewportScale) {
+		return fmt.Errorf(
+			"%w: scale must be between %g and %g",
+			ErrInvalidViewport,
+			MinViewportScale,
+			MaxViewportScale,
+		)
+	}
+	return nil
+}
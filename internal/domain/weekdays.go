@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Weekdays is a set of days of the week on which a subscription is delivered. The zero value
+// means every day.
+type Weekdays uint8
+
+// ErrInvalidWeekdays is returned when a list of days cannot be parsed.
+var ErrInvalidWeekdays = errors.New("invalid days of the week")
+
+// weekdayNames are the abbreviations produced for each time.Weekday. Full names are accepted too.
+var weekdayNames = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+const (
+	// WeekdaysWorkweek selects Monday to Friday.
+	WeekdaysWorkweek Weekdays = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday |
+		1<<time.Thursday | 1<<time.Friday
+	// WeekdaysWeekend selects Saturday and Sunday.
+	WeekdaysWeekend Weekdays = 1<<time.Saturday | 1<<time.Sunday
+	allWeekdays              = WeekdaysWorkweek | WeekdaysWeekend
+)
+
+// ParseWeekdays parses a comma-separated list of day abbreviations such as "mon,wed,fri", or one
+// of "weekdays", "weekends" and "daily". An empty value, or every day, yields the zero Weekdays.
+func ParseWeekdays(value string) (Weekdays, error) {
+	var days Weekdays
+	for _, field := range strings.Split(strings.ToLower(value), ",") {
+		switch name := strings.TrimSpace(field); name {
+		case "":
+		case "daily":
+			days |= allWeekdays
+		case "weekdays":
+			days |= WeekdaysWorkweek
+		case "weekends":
+			days |= WeekdaysWeekend
+		default:
+			day, ok := parseWeekday(name)
+			if !ok {
+				return 0, fmt.Errorf("%w: unknown day %q", ErrInvalidWeekdays, name)
+			}
+			days |= 1 << day
+		}
+	}
+
+	if days == allWeekdays {
+		return 0, nil
+	}
+	return days, nil
+}
+
+// parseWeekday accepts a lower-case three-letter abbreviation or full day name, e.g. "mon" or
+// "monday".
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day, abbreviation := range weekdayNames {
+		if name == abbreviation || name == strings.ToLower(time.Weekday(day).String()) {
+			return time.Weekday(day), true
+		}
+	}
+	return 0, false
+}
+
+// Contains reports whether deliveries are made on day.
+func (w Weekdays) Contains(day time.Weekday) bool {
+	return w == 0 || w&(1<<day) != 0
+}
+
+// String renders w in the form accepted by ParseWeekdays, starting with Monday. Every day renders
+// as an empty string.
+func (w Weekdays) String() string {
+	if w == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(weekdayNames))
+	for offset := range weekdayNames {
+		day := time.Weekday((offset + 1) % len(weekdayNames))
+		if w.Contains(day) {
+			names = append(names, weekdayNames[day])
+		}
+	}
+
+	return strings.Join(names, ",")
+}
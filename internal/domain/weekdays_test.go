@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWeekdays(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Weekdays
+	}{
+		{value: "", want: 0},
+		{value: "daily", want: 0},
+		{value: "mon", want: 1 << time.Monday},
+		{value: "Monday", want: 1 << time.Monday},
+		{value: "mon, WED ,friday", want: 1<<time.Monday | 1<<time.Wednesday | 1<<time.Friday},
+		{value: "weekdays", want: WeekdaysWorkweek},
+		{value: "weekends,sun", want: WeekdaysWeekend},
+		{value: "weekdays,sat,sun", want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWeekdays(tt.value)
+		if err != nil {
+			t.Errorf("ParseWeekdays(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWeekdays(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseWeekdaysRejectsInvalidNames(t *testing.T) {
+	for _, value := range []string{"monkey", "satan", "sundays", "mo", "tues", "mon,thurs", "7"} {
+		if got, err := ParseWeekdays(value); !errors.Is(err, ErrInvalidWeekdays) {
+			t.Errorf("ParseWeekdays(%q) = %q, %v, want ErrInvalidWeekdays", value, got, err)
+		}
+	}
+}
+
+func TestWeekdaysStringRoundTrips(t *testing.T) {
+	days := Weekdays(1<<time.Sunday | 1<<time.Monday | 1<<time.Thursday)
+	if got := days.String(); got != "mon,thu,sun" {
+		t.Fatalf("String() = %q, want %q", got, "mon,thu,sun")
+	}
+
+	parsed, err := ParseWeekdays(days.String())
+	if err != nil || parsed != days {
+		t.Errorf("ParseWeekdays(%q) = %q, %v, want %q", days.String(), parsed, err, days)
+	}
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// clockTimeLayouts are the textual forms a time-of-day column may be read back in: bare clock
+// times from MySQL TIME columns and full timestamps written by the SQLite driver, which has no
+// native TIME type.
+var clockTimeLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// clockTime reads the legacy time_of_day column, which drivers return as a time.Time or as text,
+// while it is converted to seconds since midnight.
+type clockTime time.Time
+
+// Scan implements sql.Scanner.
+func (c *clockTime) Scan(src any) error {
+	switch value := src.(type) {
+	case time.Time:
+		*c = clockTime(value)
+		return nil
+	case []byte:
+		return c.parse(string(value))
+	case string:
+		return c.parse(value)
+	default:
+		return fmt.Errorf("cannot scan %T into a time of day", src)
+	}
+}
+
+func (c *clockTime) parse(value string) error {
+	for _, layout := range clockTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			*c = clockTime(parsed)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot parse %q as a time of day", value)
+}
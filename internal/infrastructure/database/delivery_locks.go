@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// deliveryLockRetention is how long claimed deliveries are kept before they are pruned.
+const deliveryLockRetention = 7 * 24 * time.Hour
+
+// deliveryLockRecord marks one scheduled delivery of a subscription as taken by an instance.
+type deliveryLockRecord struct {
+	SubscriptionID uint      `gorm:"column:subscription_id;primaryKey;autoIncrement:false"`
+	ScheduledAt    time.Time `gorm:"column:scheduled_at;primaryKey;index:idx_delivery_locks_scheduled"`
+	Owner          string    `gorm:"column:owner;size:128;not null;default:''"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (deliveryLockRecord) TableName() string {
+	return "delivery_locks"
+}
+
+// ClaimDelivery records that owner makes the delivery of the subscription stored under id
+// scheduled for scheduledAt, and reports false when another instance already claimed it. Claims
+// older than a week are pruned along the way.
+func (s *SubscriptionStore) ClaimDelivery(
+	ctx context.Context,
+	id uint,
+	scheduledAt time.Time,
+	owner string,
+) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, fmt.Errorf("subscription store not initialised")
+	}
+
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveryLockRecord{
+			SubscriptionID: id,
+			ScheduledAt:    scheduledAt.UTC(),
+			Owner:          owner,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("claim delivery: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	// Pruning is best effort: a stale claim only takes up space and is retried on the next claim.
+	s.db.WithContext(ctx).
+		Where("scheduled_at < ?", scheduledAt.UTC().Add(-deliveryLockRetention)).
+		Delete(&deliveryLockRecord{})
+
+	return true, nil
+}
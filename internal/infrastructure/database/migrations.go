@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migration is a schema change that AutoMigrate cannot express, such as dropping or renaming a
+// column or backfilling data. Migrations run once, in version order, and are recorded in the
+// schema_migrations table.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *gorm.DB) error
+}
+
+// migrations lists every versioned migration. Append new entries with the next version; never
+// edit or reorder released ones.
+var migrations = []migration{
+	{
+		// Schemas created before versioning were managed by AutoMigrate alone and need no change.
+		version: 1,
+		name:    "baseline",
+		up:      func(*gorm.DB) error { return nil },
+	},
+	{
+		version: 2,
+		name:    "store time of day as seconds since midnight",
+		up:      migrateSecondsOfDay,
+	},
+}
+
+// migrateSecondsOfDay replaces the time_of_day TIME column, whose handling differs between
+// drivers, with an integer seconds_of_day column. Values are converted in Go so the migration
+// works the same on every database.
+func migrateSecondsOfDay(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	if !migrator.HasColumn(&subscriptionRecord{}, "time_of_day") {
+		return nil
+	}
+	if !migrator.HasColumn(&subscriptionRecord{}, "seconds_of_day") {
+		if err := migrator.AddColumn(&subscriptionRecord{}, "SecondsOfDay"); err != nil {
+			return err
+		}
+	}
+
+	var rows []struct {
+		ID        uint
+		TimeOfDay clockTime
+	}
+	if err := tx.Table(subscriptionRecord{}.TableName()).
+		Select("id", "time_of_day").
+		Find(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := tx.Table(subscriptionRecord{}.TableName()).
+			Where("id = ?", row.ID).
+			Update("seconds_of_day", secondsOfDay(time.Time(row.TimeOfDay))).Error; err != nil {
+			return err
+		}
+	}
+
+	return migrator.DropColumn(&subscriptionRecord{}, "time_of_day")
+}
+
+type schemaMigrationRecord struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigrationRecord) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate brings the schema up to date. Pending versioned migrations run against the existing
+// tables first, each in its own transaction, and AutoMigrate then adds new tables, columns and
+// indexes. A fresh install is created by AutoMigrate directly and every migration is recorded as
+// applied, since it already has the final schema.
+func (s *SubscriptionStore) Migrate(ctx context.Context) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	db := s.db.WithContext(ctx)
+	fresh := !db.Migrator().HasTable(&subscriptionRecord{})
+	if err := db.AutoMigrate(&schemaMigrationRecord{}); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var applied []int
+	if err := db.Model(&schemaMigrationRecord{}).Pluck("version", &applied).Error; err != nil {
+		return fmt.Errorf("read applied migrations: %w", err)
+	}
+	done := make(map[int]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.version] {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if !fresh {
+				if err := m.up(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Create(&schemaMigrationRecord{
+				Version:   m.version,
+				Name:      m.name,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+
+	return s.AutoMigrate(ctx)
+}
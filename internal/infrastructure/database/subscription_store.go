@@ -2,15 +2,15 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sglre6355/weather-lady/internal/domain"
 	"gorm.io/gorm"
 )
 
-const referenceYear = 2000
-
 // SubscriptionStore persists subscriptions using GORM.
 type SubscriptionStore struct {
 	db *gorm.DB
@@ -21,41 +21,99 @@ func NewSubscriptionStore(db *gorm.DB) *SubscriptionStore {
 	return &SubscriptionStore{db: db}
 }
 
-// AutoMigrate ensures the subscriptions table exists with the expected schema.
+// AutoMigrate ensures the subscriptions and delivery_locks tables exist with the expected schema.
 func (s *SubscriptionStore) AutoMigrate(ctx context.Context) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("subscription store not initialised")
 	}
 
-	return s.db.WithContext(ctx).AutoMigrate(&subscriptionRecord{})
+	return s.db.WithContext(ctx).AutoMigrate(&subscriptionRecord{}, &deliveryLockRecord{})
 }
 
-// Create persists the provided subscription.
-func (s *SubscriptionStore) Create(ctx context.Context, subscription domain.Subscription) error {
+// Create persists the provided subscription and returns it with its assigned ID.
+func (s *SubscriptionStore) Create(
+	ctx context.Context,
+	subscription domain.Subscription,
+) (domain.Subscription, error) {
 	if s == nil || s.db == nil {
-		return fmt.Errorf("subscription store not initialised")
+		return domain.Subscription{}, fmt.Errorf("subscription store not initialised")
 	}
 
-	record := subscriptionRecord{
-		ChannelID:       subscription.ChannelID,
-		GuildID:         subscription.GuildID,
-		TimeOfDay:       timeOfDay(subscription.Time),
-		URL:             subscription.URL,
-		ElementSelector: subscription.ElementSelector,
-		Message:         subscription.Message,
+	record := toSubscriptionRecord(subscription)
+	if err := s.db.WithContext(ctx).Create(&record).Error; err != nil {
+		return domain.Subscription{}, err
 	}
 
-	return s.db.WithContext(ctx).Create(&record).Error
+	return toDomainSubscription(record), nil
 }
 
-// List returns every persisted subscription.
+// List returns every persisted subscription in creation order, so restoration is reproducible
+// across databases.
 func (s *SubscriptionStore) List(ctx context.Context) ([]domain.Subscription, error) {
 	if s == nil || s.db == nil {
 		return nil, fmt.Errorf("subscription store not initialised")
 	}
 
 	var records []subscriptionRecord
-	if err := s.db.WithContext(ctx).Find(&records).Error; err != nil {
+	if err := s.db.WithContext(ctx).Order("created_at, id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainSubscriptions(records), nil
+}
+
+// ListPaged returns up to limit subscriptions after skipping offset, in the order used by List.
+func (s *SubscriptionStore) ListPaged(
+	ctx context.Context,
+	offset, limit int,
+) ([]domain.Subscription, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("subscription store not initialised")
+	}
+
+	var records []subscriptionRecord
+	if err := s.db.WithContext(ctx).
+		Order("created_at, id").
+		Offset(offset).
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainSubscriptions(records), nil
+}
+
+// FindByID returns the subscription stored under id, or domain.ErrSubscriptionNotFound.
+func (s *SubscriptionStore) FindByID(ctx context.Context, id uint) (domain.Subscription, error) {
+	if s == nil || s.db == nil {
+		return domain.Subscription{}, fmt.Errorf("subscription store not initialised")
+	}
+
+	var record subscriptionRecord
+	if err := s.db.WithContext(ctx).First(&record, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Subscription{}, domain.ErrSubscriptionNotFound
+		}
+		return domain.Subscription{}, err
+	}
+
+	return toDomainSubscription(record), nil
+}
+
+// ListByChannel returns every subscription configured for channelID.
+func (s *SubscriptionStore) ListByChannel(
+	ctx context.Context,
+	channelID string,
+) ([]domain.Subscription, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("subscription store not initialised")
+	}
+
+	var records []subscriptionRecord
+	if err := s.db.WithContext(ctx).
+		Where("channel_id = ?", channelID).
+		Order("id").
+		Find(&records).Error; err != nil {
 		return nil, err
 	}
 
@@ -79,6 +137,130 @@ func (s *SubscriptionStore) ListByGuild(
 	return toDomainSubscriptions(records), nil
 }
 
+// CountByGuild returns how many subscriptions are configured for guildID.
+func (s *SubscriptionStore) CountByGuild(ctx context.Context, guildID string) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("subscription store not initialised")
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("guild_id = ?", guildID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// UpdateOwner records userID as the owner of the subscription stored under id.
+func (s *SubscriptionStore) UpdateOwner(ctx context.Context, id uint, userID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	result := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", id).
+		Update("created_by_user_id", userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// UpdatePaused pauses or resumes deliveries of the subscription stored under id.
+func (s *SubscriptionStore) UpdatePaused(ctx context.Context, id uint, paused bool) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	result := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", id).
+		Update("paused", paused)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// ClearFirstDeliveryConfirmation records that the first delivery of the subscription stored under
+// id has been confirmed to its owner.
+func (s *SubscriptionStore) ClearFirstDeliveryConfirmation(ctx context.Context, id uint) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	return s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", id).
+		Update("confirm_first_delivery", false).Error
+}
+
+// UpdateNextRun records nextRunAt as the next delivery of the subscription stored under id.
+func (s *SubscriptionStore) UpdateNextRun(ctx context.Context, id uint, nextRunAt time.Time) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	return s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", id).
+		Update("next_run_at", nextRunAt.UTC()).Error
+}
+
+// ClaimNextRun moves the recorded next delivery of the subscription stored under id from missed
+// to nextRunAt, and reports false when the record no longer holds missed, e.g. because another
+// instance claimed it first.
+func (s *SubscriptionStore) ClaimNextRun(
+	ctx context.Context,
+	id uint,
+	missed time.Time,
+	nextRunAt time.Time,
+) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, fmt.Errorf("subscription store not initialised")
+	}
+
+	result := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ? AND next_run_at = ?", id, missed.UTC()).
+		Update("next_run_at", nextRunAt.UTC())
+	return result.RowsAffected == 1, result.Error
+}
+
+// ListDueBetween returns the subscriptions whose next delivery falls in [start, end), earliest
+// first. Subscriptions that have never been scheduled are not included.
+func (s *SubscriptionStore) ListDueBetween(
+	ctx context.Context,
+	start time.Time,
+	end time.Time,
+) ([]domain.Subscription, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("subscription store not initialised")
+	}
+
+	var records []subscriptionRecord
+	if err := s.db.WithContext(ctx).
+		Where("next_run_at >= ? AND next_run_at < ?", start.UTC(), end.UTC()).
+		Order("next_run_at, id").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainSubscriptions(records), nil
+}
+
 // DeleteByChannel removes every subscription stored against channelID and returns the number removed.
 func (s *SubscriptionStore) DeleteByChannel(ctx context.Context, channelID string) (int, error) {
 	if s == nil || s.db == nil {
@@ -89,68 +271,210 @@ func (s *SubscriptionStore) DeleteByChannel(ctx context.Context, channelID strin
 	return int(result.RowsAffected), result.Error
 }
 
+// UpdateByID overwrites the settings of the subscription stored under subscription.ID in place.
+// Its creation time and recorded next run are kept. Returns domain.ErrSubscriptionNotFound when
+// no such row exists, e.g. because it was removed concurrently.
+func (s *SubscriptionStore) UpdateByID(ctx context.Context, subscription domain.Subscription) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("subscription store not initialised")
+	}
+
+	record := toSubscriptionRecord(subscription)
+	result := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", subscription.ID).
+		Select("*").
+		Omit("id", "created_at", "next_run_at").
+		Updates(&record)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+
+	// MySQL reports rows whose values did not change as unaffected, so confirm the row is gone.
+	var count int64
+	if err := s.db.WithContext(ctx).
+		Model(&subscriptionRecord{}).
+		Where("id = ?", subscription.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// DeleteByID removes the subscription stored under id and reports whether it existed.
+func (s *SubscriptionStore) DeleteByID(ctx context.Context, id uint) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, fmt.Errorf("subscription store not initialised")
+	}
+
+	result := s.db.WithContext(ctx).Delete(&subscriptionRecord{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
 type subscriptionRecord struct {
-	ID              uint      `gorm:"primaryKey"`
-	ChannelID       string    `gorm:"column:channel_id;size:128;not null;index:idx_subscriptions_channel"`
-	GuildID         string    `gorm:"column:guild_id;size:128;not null;index:idx_subscriptions_guild"`
-	TimeOfDay       time.Time `gorm:"column:time_of_day;type:time;not null"`
-	URL             string    `gorm:"column:url;type:text;not null"`
-	ElementSelector string    `gorm:"column:element_selector;type:text;not null"`
-	Message         string    `gorm:"column:message;type:text;not null"`
-	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	ID                   uint       `gorm:"primaryKey"`
+	ChannelID            string     `gorm:"column:channel_id;size:128;not null;index:idx_subscriptions_channel"`
+	GuildID              string     `gorm:"column:guild_id;size:128;not null;index:idx_subscriptions_guild"`
+	ExtraChannelIDs      string     `gorm:"column:extra_channel_ids;size:255;not null;default:''"`
+	Label                string     `gorm:"column:label;size:320;not null;default:''"`
+	CreatedByUserID      string     `gorm:"column:created_by_user_id;size:128;not null;default:''"`
+	UpdatedByUserID      string     `gorm:"column:updated_by_user_id;size:128;not null;default:''"`
+	SecondsOfDay         int        `gorm:"column:seconds_of_day;not null;default:0"`
+	URL                  string     `gorm:"column:url;type:text;not null"`
+	ElementSelector      string     `gorm:"column:element_selector;type:text;not null"`
+	Message              string     `gorm:"column:message;type:text;not null"`
+	ReplyToMessageID     string     `gorm:"column:reply_to_message_id;size:32;not null;default:''"`
+	MatchIndex           int        `gorm:"column:match_index;not null;default:0"`
+	Region               string     `gorm:"column:region;size:64;not null;default:''"`
+	Viewport             string     `gorm:"column:viewport;size:32;not null;default:''"`
+	Alignment            string     `gorm:"column:alignment;size:16;not null;default:wall_clock"`
+	Days                 string     `gorm:"column:days;size:32;not null;default:''"`
+	IntervalSeconds      int64      `gorm:"column:interval_seconds;not null;default:0"`
+	Quality              int        `gorm:"column:quality;not null;default:0"`
+	Background           string     `gorm:"column:background;size:7;not null;default:''"`
+	Format               string     `gorm:"column:format;size:16;not null;default:png"`
+	Language             string     `gorm:"column:language;size:35;not null;default:''"`
+	Timezone             string     `gorm:"column:timezone;size:64;not null;default:''"`
+	ForecastDays         string     `gorm:"column:forecast_days;size:64;not null;default:''"`
+	Framed               bool       `gorm:"column:framed;not null;default:false"`
+	Mode                 string     `gorm:"column:mode;size:16;not null;default:fixed"`
+	MaxStaleSeconds      int64      `gorm:"column:max_stale_seconds;not null;default:0"`
+	TimeoutSeconds       int64      `gorm:"column:capture_timeout_seconds;not null;default:0"`
+	ConfirmFirstDelivery bool       `gorm:"column:confirm_first_delivery;not null;default:false"`
+	ErrorNotifyChannelID string     `gorm:"column:error_notify_channel_id;size:128;not null;default:''"`
+	WebhookURL           string     `gorm:"column:webhook_url;size:512;not null;default:''"`
+	Keywords             string     `gorm:"column:keywords;type:text;not null"`
+	Paused               bool       `gorm:"column:paused;not null;default:false"`
+	NextRunAt            *time.Time `gorm:"column:next_run_at;index:idx_subscriptions_next_run"`
+	CreatedAt            time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt            time.Time  `gorm:"column:updated_at;autoUpdateTime"`
 }
 
 func (subscriptionRecord) TableName() string {
 	return "subscriptions"
 }
 
-func timeOfDay(input time.Time) time.Time {
-	loc := input.Location()
-	if loc == nil {
-		loc = time.UTC
-	}
-	return time.Date(
-		referenceYear,
-		time.January,
-		1,
-		input.Hour(),
-		input.Minute(),
-		input.Second(),
-		0,
-		loc,
-	)
-}
-
-func fromTimeOfDay(stored time.Time) time.Time {
-	loc := stored.Location()
-	if loc == nil {
-		loc = time.UTC
-	}
-	return time.Date(
-		referenceYear,
-		time.January,
-		1,
-		stored.Hour(),
-		stored.Minute(),
-		stored.Second(),
-		0,
-		loc,
-	)
+// secondsPerDay bounds the seconds_of_day column.
+const secondsPerDay = 24 * 60 * 60
+
+// secondsOfDay converts the wall-clock time of input to seconds since midnight.
+func secondsOfDay(input time.Time) int {
+	hour, minute, second := input.Clock()
+	return hour*3600 + minute*60 + second
+}
+
+// fromSecondsOfDay converts seconds since midnight back to a time of day on the zero date.
+func fromSecondsOfDay(seconds int) time.Time {
+	seconds = ((seconds % secondsPerDay) + secondsPerDay) % secondsPerDay
+	return time.Date(0, time.January, 1, 0, 0, seconds, 0, time.UTC)
+}
+
+func toSubscriptionRecord(subscription domain.Subscription) subscriptionRecord {
+	return subscriptionRecord{
+		ID:                   subscription.ID,
+		ChannelID:            subscription.ChannelID,
+		GuildID:              subscription.GuildID,
+		ExtraChannelIDs:      strings.Join(subscription.ExtraChannelIDs, ","),
+		Label:                subscription.Label,
+		CreatedByUserID:      subscription.CreatedByUserID,
+		UpdatedByUserID:      subscription.UpdatedByUserID,
+		SecondsOfDay:         secondsOfDay(subscription.Time),
+		URL:                  subscription.URL,
+		ElementSelector:      subscription.ElementSelector,
+		Message:              subscription.Message,
+		ReplyToMessageID:     subscription.ReplyToMessageID,
+		MatchIndex:           subscription.MatchIndex,
+		Region:               subscription.Region.String(),
+		Viewport:             subscription.Viewport.String(),
+		Alignment:            string(subscription.Alignment.OrDefault()),
+		Days:                 subscription.Days.String(),
+		IntervalSeconds:      int64(subscription.EveryN / time.Second),
+		Format:               string(subscription.Format.OrDefault()),
+		Quality:              subscription.Quality,
+		Background:           subscription.Background,
+		Language:             subscription.Language,
+		Timezone:             subscription.Timezone,
+		ForecastDays:         domain.FormatForecastDays(subscription.ForecastDays),
+		Framed:               subscription.Framed,
+		Mode:                 string(subscription.Mode.OrDefault()),
+		MaxStaleSeconds:      int64(subscription.MaxStaleness / time.Second),
+		TimeoutSeconds:       int64(subscription.CaptureTimeout / time.Second),
+		ConfirmFirstDelivery: subscription.ConfirmFirstDelivery,
+		ErrorNotifyChannelID: subscription.ErrorNotifyChannelID,
+		WebhookURL:           subscription.WebhookURL,
+		Keywords:             domain.FormatKeywords(subscription.Keywords),
+		Paused:               subscription.Paused,
+	}
+}
+
+func toDomainSubscription(record subscriptionRecord) domain.Subscription {
+	// Offsets, regions, keywords and days are validated before being written, so a parse failure can
+	// only come from manual edits; fall back to a single, unfiltered, selector-based capture
+	// rather than refusing to restore the row.
+	forecastDays, _ := domain.ParseForecastDays(record.ForecastDays)
+	region, _ := domain.ParseRegion(record.Region)
+	viewport, _ := domain.ParseViewport(record.Viewport)
+	keywords, _ := domain.ParseKeywords(record.Keywords)
+	days, _ := domain.ParseWeekdays(record.Days)
+
+	var nextRunAt time.Time
+	if record.NextRunAt != nil {
+		nextRunAt = *record.NextRunAt
+	}
+
+	return domain.Subscription{
+		ID:                   record.ID,
+		ChannelID:            record.ChannelID,
+		GuildID:              record.GuildID,
+		ExtraChannelIDs:      splitChannelIDs(record.ExtraChannelIDs),
+		Label:                record.Label,
+		CreatedByUserID:      record.CreatedByUserID,
+		UpdatedByUserID:      record.UpdatedByUserID,
+		Time:                 fromSecondsOfDay(record.SecondsOfDay),
+		URL:                  record.URL,
+		ElementSelector:      record.ElementSelector,
+		Message:              record.Message,
+		ReplyToMessageID:     record.ReplyToMessageID,
+		MatchIndex:           record.MatchIndex,
+		Region:               region,
+		Viewport:             viewport,
+		Alignment:            domain.Alignment(record.Alignment).OrDefault(),
+		Days:                 days,
+		EveryN:               time.Duration(record.IntervalSeconds) * time.Second,
+		Format:               domain.Format(record.Format).OrDefault(),
+		Quality:              record.Quality,
+		Background:           record.Background,
+		Language:             record.Language,
+		Timezone:             record.Timezone,
+		ForecastDays:         forecastDays,
+		Framed:               record.Framed,
+		Mode:                 domain.ForecastMode(record.Mode).OrDefault(),
+		MaxStaleness:         time.Duration(record.MaxStaleSeconds) * time.Second,
+		CaptureTimeout:       time.Duration(record.TimeoutSeconds) * time.Second,
+		ConfirmFirstDelivery: record.ConfirmFirstDelivery,
+		ErrorNotifyChannelID: record.ErrorNotifyChannelID,
+		WebhookURL:           record.WebhookURL,
+		Keywords:             keywords,
+		Paused:               record.Paused,
+		NextRunAt:            nextRunAt,
+	}
 }
 
 func toDomainSubscriptions(records []subscriptionRecord) []domain.Subscription {
 	subscriptions := make([]domain.Subscription, 0, len(records))
 	for _, record := range records {
-		subscriptions = append(subscriptions, domain.Subscription{
-			ChannelID:       record.ChannelID,
-			GuildID:         record.GuildID,
-			Time:            fromTimeOfDay(record.TimeOfDay),
-			URL:             record.URL,
-			ElementSelector: record.ElementSelector,
-			Message:         record.Message,
-		})
+		subscriptions = append(subscriptions, toDomainSubscription(record))
 	}
 
 	return subscriptions
 }
+
+func splitChannelIDs(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
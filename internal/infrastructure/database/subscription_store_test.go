@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// createSubscription stores a daily subscription for channelID in guildID and returns it.
+func createSubscription(
+	t *testing.T,
+	store *SubscriptionStore,
+	guildID string,
+	channelID string,
+) domain.Subscription {
+	t.Helper()
+
+	sub, err := store.Create(context.Background(), domain.Subscription{
+		ChannelID:       channelID,
+		GuildID:         guildID,
+		Time:            time.Date(0, time.January, 1, 8, 0, 0, 0, time.UTC),
+		URL:             "https://example.com/forecast",
+		ElementSelector: "#forecast",
+		Message:         "Today's forecast",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return sub
+}
+
+func TestCountByGuild(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	removed := createSubscription(t, store, "guild", "channel")
+	createSubscription(t, store, "guild", "channel")
+	createSubscription(t, store, "guild", "other-channel")
+	createSubscription(t, store, "other-guild", "elsewhere")
+	if _, err := store.DeleteByID(ctx, removed.ID); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+
+	tests := []struct {
+		guildID string
+		want    int
+	}{
+		{guildID: "guild", want: 2},
+		{guildID: "other-guild", want: 1},
+		{guildID: "unknown-guild", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.guildID, func(t *testing.T) {
+			got, err := store.CountByGuild(ctx, tt.guildID)
+			if err != nil {
+				t.Fatalf("CountByGuild: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CountByGuild(%q) = %d, want %d", tt.guildID, got, tt.want)
+			}
+		})
+	}
+}
+
+// subscriptionIDs returns the IDs of subs in order.
+func subscriptionIDs(subs []domain.Subscription) []uint {
+	ids := make([]uint, len(subs))
+	for i, sub := range subs {
+		ids[i] = sub.ID
+	}
+	return ids
+}
+
+func TestListOrder(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	// Rows imported or written by instances with skewed clocks are not created in ID order;
+	// rows created in the same instant fall back to ID order.
+	created := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{2 * time.Hour, 0, 0, time.Hour}
+	subs := make([]domain.Subscription, len(offsets))
+	for i, offset := range offsets {
+		subs[i] = createSubscription(t, store, "guild", "channel")
+		if err := store.db.Model(&subscriptionRecord{}).
+			Where("id = ?", subs[i].ID).
+			Update("created_at", created.Add(offset)).Error; err != nil {
+			t.Fatalf("set created_at: %v", err)
+		}
+	}
+	want := []uint{subs[1].ID, subs[2].ID, subs[3].ID, subs[0].ID}
+
+	listed, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := subscriptionIDs(listed); !slices.Equal(got, want) {
+		t.Errorf("List IDs = %v, want %v", got, want)
+	}
+
+	var paged []uint
+	for offset := 0; offset < len(want); offset += 3 {
+		page, err := store.ListPaged(ctx, offset, 3)
+		if err != nil {
+			t.Fatalf("ListPaged: %v", err)
+		}
+		paged = append(paged, subscriptionIDs(page)...)
+	}
+	if !slices.Equal(paged, want) {
+		t.Errorf("ListPaged IDs = %v, want %v", paged, want)
+	}
+}
+
+func TestListDueBetween(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	start := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// Each subscription is stored in its own channel, named after its next run.
+	scheduled := []struct {
+		channelID string
+		nextRun   time.Time
+	}{
+		{channelID: "before", nextRun: start.Add(-time.Second)},
+		{channelID: "at start", nextRun: start},
+		{channelID: "late", nextRun: start.Add(30 * time.Minute)},
+		{channelID: "early", nextRun: start.Add(10 * time.Minute)},
+		{channelID: "same as late", nextRun: start.Add(30 * time.Minute).In(tokyo)},
+		{channelID: "at end", nextRun: end},
+		{channelID: "after", nextRun: end.Add(time.Minute)},
+	}
+	ids := make(map[string]uint, len(scheduled))
+	nextRuns := make(map[string]time.Time, len(scheduled))
+	for _, s := range scheduled {
+		sub := createSubscription(t, store, "guild", s.channelID)
+		if err := store.UpdateNextRun(ctx, sub.ID, s.nextRun); err != nil {
+			t.Fatalf("UpdateNextRun: %v", err)
+		}
+		ids[s.channelID] = sub.ID
+		nextRuns[s.channelID] = s.nextRun
+	}
+	createSubscription(t, store, "guild", "never scheduled")
+
+	// The window is given in another zone than the stored instants.
+	due, err := store.ListDueBetween(ctx, start.In(tokyo), end.In(tokyo))
+	if err != nil {
+		t.Fatalf("ListDueBetween: %v", err)
+	}
+
+	want := []uint{ids["at start"], ids["early"], ids["late"], ids["same as late"]}
+	if got := subscriptionIDs(due); !slices.Equal(got, want) {
+		t.Fatalf("ListDueBetween IDs = %v, want %v", got, want)
+	}
+	for _, sub := range due {
+		if want := nextRuns[sub.ChannelID]; !sub.NextRunAt.Equal(want) {
+			t.Errorf("%s: NextRunAt = %v, want %v", sub.ChannelID, sub.NextRunAt, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+const (
+	deliveryEventSucceeded = "delivery.succeeded"
+	deliveryEventFailed    = "delivery.failed"
+)
+
+// DeliveryWebhook posts delivery outcomes as JSON events to an operations endpoint.
+type DeliveryWebhook struct {
+	url    string
+	client *http.Client
+}
+
+// deliveryEvent is the JSON payload posted for every delivery outcome.
+type deliveryEvent struct {
+	Event          string    `json:"event"`
+	SubscriptionID uint      `json:"subscription_id"`
+	ChannelID      string    `json:"channel_id"`
+	GuildID        string    `json:"guild_id"`
+	Stage          string    `json:"stage,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// NewDeliveryWebhook returns a notifier posting to url, bounding every request by timeout.
+func NewDeliveryWebhook(url string, timeout time.Duration) *DeliveryWebhook {
+	return &DeliveryWebhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// NotifySuccess reports a successful delivery of sub.
+func (w *DeliveryWebhook) NotifySuccess(ctx context.Context, sub domain.Subscription) error {
+	return w.post(ctx, deliveryEvent{
+		Event:          deliveryEventSucceeded,
+		SubscriptionID: sub.ID,
+		ChannelID:      sub.ChannelID,
+		GuildID:        sub.GuildID,
+		Timestamp:      time.Now().UTC(),
+	})
+}
+
+// NotifyFailure reports that delivering sub failed during stage.
+func (w *DeliveryWebhook) NotifyFailure(
+	ctx context.Context,
+	sub domain.Subscription,
+	stage string,
+	cause error,
+) error {
+	event := deliveryEvent{
+		Event:          deliveryEventFailed,
+		SubscriptionID: sub.ID,
+		ChannelID:      sub.ChannelID,
+		GuildID:        sub.GuildID,
+		Stage:          stage,
+		Timestamp:      time.Now().UTC(),
+	}
+	if cause != nil {
+		event.Error = cause.Error()
+	}
+
+	return w.post(ctx, event)
+}
+
+func (w *DeliveryWebhook) post(ctx context.Context, event deliveryEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode delivery event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build delivery event request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post delivery event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post delivery event: unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// maxArchiveNameAttempts bounds how many numbered names are tried for deliveries archived in the
+// same second.
+const maxArchiveNameAttempts = 100
+
+// FileForecastSender archives every delivered capture as a file under a directory, in one
+// subdirectory per channel, e.g. <dir>/<channel ID>/20260101-080000.png. Further deliveries in the
+// same second are numbered (20260101-080000_2.png), and the images of deliveries of several
+// forecast days after the first get a -2, -3, ... suffix.
+type FileForecastSender struct {
+	dir   string
+	nowFn func() time.Time
+}
+
+// NewFileForecastSender returns a sender that archives captures under dir, creating it as needed.
+func NewFileForecastSender(dir string) *FileForecastSender {
+	return &FileForecastSender{
+		dir:   dir,
+		nowFn: time.Now,
+	}
+}
+
+// SendForecast writes the images of delivery to the channel's directory. Existing files are never
+// overwritten.
+func (s *FileForecastSender) SendForecast(ctx context.Context, delivery domain.Delivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(delivery.Images) == 0 {
+		return nil
+	}
+
+	channelDir := filepath.Join(s.dir, filepath.Base(delivery.ChannelID))
+	if err := os.MkdirAll(channelDir, 0o755); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+
+	// The first image claims a name no other delivery uses, and the others are named after it.
+	stamp := s.nowFn().UTC().Format("20060102-150405")
+	extension := string(delivery.Format.OrDefault())
+	base := ""
+	for attempt := 1; attempt <= maxArchiveNameAttempts; attempt++ {
+		candidate := stamp
+		if attempt > 1 {
+			candidate = fmt.Sprintf("%s_%d", stamp, attempt)
+		}
+		err := writeNewFile(
+			filepath.Join(channelDir, candidate+"."+extension),
+			delivery.Images[0],
+		)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("archive forecast: %w", err)
+		}
+		base = candidate
+		break
+	}
+	if base == "" {
+		return fmt.Errorf("archive forecast: no free file name for %s in %s", stamp, channelDir)
+	}
+
+	for index, imageData := range delivery.Images[1:] {
+		name := fmt.Sprintf("%s-%d.%s", base, index+2, extension)
+		if err := writeNewFile(filepath.Join(channelDir, name), imageData); err != nil {
+			return fmt.Errorf("archive forecast: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeNewFile writes data to path, failing when the file already exists.
+func writeNewFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+// flatten composites src over an opaque background, removing any transparency.
+func flatten(src image.Image, background color.Color) *image.RGBA {
+	bounds := src.Bounds()
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, &image.Uniform{C: background}, image.Point{}, draw.Src)
+	draw.Draw(canvas, bounds, src, bounds.Min, draw.Over)
+	return canvas
+}
+
+// flattenPNG re-encodes a PNG capture with its transparent regions filled with background, a
+// normalised "#rrggbb" color.
+func flattenPNG(imageData []byte, background string) ([]byte, error) {
+	var fill color.RGBA
+	if _, err := fmt.Sscanf(background, "#%02x%02x%02x", &fill.R, &fill.G, &fill.B); err != nil {
+		return nil, fmt.Errorf("parse background color %q: %w", background, err)
+	}
+	fill.A = 0xff
+
+	src, err := png.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, flatten(src, fill)); err != nil {
+		return nil, fmt.Errorf("encode image: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// encodeJPEG re-encodes an opaque PNG capture as JPEG at quality (1-100).
+func encodeJPEG(imageData []byte, quality int) ([]byte, error) {
+	src, err := png.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, src, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("encode image: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "weather_lady"
+
+// PrometheusMetrics records bot activity in a dedicated Prometheus registry.
+type PrometheusMetrics struct {
+	registry *prometheus.Registry
+
+	subscriptionsCreated prometheus.Counter
+	subscriptionsRemoved prometheus.Counter
+	subscriptionsActive  prometheus.Gauge
+	capturesRateLimited  prometheus.Counter
+	capturesAttempted    prometheus.Counter
+	dispatchesAttempted  prometheus.Counter
+	deliveryFailures     *prometheus.CounterVec
+	captureDuration      *prometheus.HistogramVec
+	captureSize          prometheus.Histogram
+}
+
+// NewPrometheusMetrics registers the bot's collectors, together with the Go runtime and process
+// collectors, in a fresh registry.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		subscriptionsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "subscriptions_created_total",
+			Help:      "Number of subscriptions created.",
+		}),
+		subscriptionsRemoved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "subscriptions_removed_total",
+			Help:      "Number of subscriptions removed.",
+		}),
+		subscriptionsActive: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "subscriptions_active",
+			Help:      "Number of subscriptions currently scheduled.",
+		}),
+		capturesRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "scheduled_captures_rate_limited_total",
+			Help:      "Number of scheduled deliveries skipped by the per-guild capture limit.",
+		}),
+		capturesAttempted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "scheduled_captures_total",
+			Help:      "Number of captures requested for scheduled deliveries, including retries.",
+		}),
+		dispatchesAttempted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "dispatches_total",
+			Help:      "Number of scheduled deliveries sent to a channel.",
+		}),
+		deliveryFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "delivery_failures_total",
+			Help:      "Number of scheduled delivery failures by pipeline stage.",
+		}, []string{"stage"}),
+		captureDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "capture_duration_seconds",
+			Help:      "Time taken by captures made for subscriptions, by result.",
+			Buckets:   []float64{0.5, 1, 2, 5, 10, 15, 20, 30, 45, 60},
+		}, []string{"result"}),
+		captureSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "capture_size_bytes",
+			Help:      "Size of successful captures made for subscriptions.",
+			Buckets:   prometheus.ExponentialBuckets(16*1024, 2, 10),
+		}),
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.subscriptionsCreated,
+		m.subscriptionsRemoved,
+		m.subscriptionsActive,
+		m.capturesRateLimited,
+		m.capturesAttempted,
+		m.dispatchesAttempted,
+		m.deliveryFailures,
+		m.captureDuration,
+		m.captureSize,
+	)
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// SubscriptionCreated counts one new subscription.
+func (m *PrometheusMetrics) SubscriptionCreated() {
+	m.subscriptionsCreated.Inc()
+}
+
+// SubscriptionsRemoved counts count removed subscriptions.
+func (m *PrometheusMetrics) SubscriptionsRemoved(count int) {
+	m.subscriptionsRemoved.Add(float64(count))
+}
+
+// SetActiveSubscriptions records how many subscriptions are currently scheduled.
+func (m *PrometheusMetrics) SetActiveSubscriptions(count int) {
+	m.subscriptionsActive.Set(float64(count))
+}
+
+// CaptureRateLimited counts one scheduled delivery skipped by the capture limit.
+func (m *PrometheusMetrics) CaptureRateLimited() {
+	m.capturesRateLimited.Inc()
+}
+
+// CaptureAttempted counts one capture requested for a scheduled delivery.
+func (m *PrometheusMetrics) CaptureAttempted() {
+	m.capturesAttempted.Inc()
+}
+
+// DispatchAttempted counts one scheduled delivery sent to a channel.
+func (m *PrometheusMetrics) DispatchAttempted() {
+	m.dispatchesAttempted.Inc()
+}
+
+// CaptureObserved records the duration of one capture and, when it succeeded, its size in bytes.
+func (m *PrometheusMetrics) CaptureObserved(duration time.Duration, size int, err error) {
+	if err != nil {
+		m.captureDuration.WithLabelValues("failure").Observe(duration.Seconds())
+		return
+	}
+	m.captureDuration.WithLabelValues("success").Observe(duration.Seconds())
+	m.captureSize.Observe(float64(size))
+}
+
+// DeliveryFailed counts one scheduled delivery failure at stage, e.g. "capture" or "dispatch".
+func (m *PrometheusMetrics) DeliveryFailed(stage string) {
+	m.deliveryFailures.WithLabelValues(stage).Inc()
+}
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusMetricsExposeLifecycleCounters(t *testing.T) {
+	metrics := NewPrometheusMetrics()
+	metrics.SubscriptionCreated()
+	metrics.SubscriptionCreated()
+	metrics.SubscriptionCreated()
+	metrics.SubscriptionsRemoved(2)
+	metrics.SetActiveSubscriptions(1)
+	metrics.CaptureAttempted()
+	metrics.DispatchAttempted()
+	metrics.CaptureRateLimited()
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	if err != nil {
+		t.Fatalf("read metrics: %v", err)
+	}
+	exposition := string(body)
+
+	for _, want := range []string{
+		"weather_lady_subscriptions_created_total 3\n",
+		"weather_lady_subscriptions_removed_total 2\n",
+		"weather_lady_subscriptions_active 1\n",
+		"weather_lady_scheduled_captures_total 1\n",
+		"weather_lady_dispatches_total 1\n",
+		"weather_lady_scheduled_captures_rate_limited_total 1\n",
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("metrics do not contain %q", strings.TrimSpace(want))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register the JPEG decoder for image.Decode
+	_ "image/png"  // register the PNG decoder for image.Decode
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// renderPDF embeds a raster image into a single-page PDF sized to the image, one point per pixel.
+// Transparent regions are flattened onto white since DeviceRGB has no alpha channel. Captures in
+// a format that cannot be decoded here, e.g. a WebP image from a backend that ignored the requested
+// PNG, yield domain.ErrUnsupportedFormat.
+func renderPDF(imageData []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(imageData))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, fmt.Errorf(
+			"%w: capture service returned an image that cannot be embedded in a pdf",
+			domain.ErrUnsupportedFormat,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	canvas := flatten(src, color.White)
+
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]byte, 0, width*height*3)
+	for offset := 0; offset < len(canvas.Pix); offset += 4 {
+		pixels = append(pixels, canvas.Pix[offset], canvas.Pix[offset+1], canvas.Pix[offset+2])
+	}
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write(pixels); err != nil {
+		return nil, fmt.Errorf("compress image: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("compress image: %w", err)
+	}
+
+	content := fmt.Sprintf("q %d 0 0 %d 0 0 cm /Im0 Do Q", width, height)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+				"/Resources << /XObject << /Im0 5 0 R >> >> /Contents 4 0 R >>",
+			width,
+			height,
+		),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB "+
+				"/BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			width,
+			height,
+			compressed.Len(),
+			compressed.Bytes(),
+		),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for index, object := range objects {
+		offsets[index] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", index+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&out,
+		"trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1,
+		xref,
+	)
+
+	return out.Bytes(), nil
+}
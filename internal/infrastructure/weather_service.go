@@ -3,59 +3,383 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
 	web_capture "github.com/sglre6355/weather-lady/gen/web_capture/v1"
+	"github.com/sglre6355/weather-lady/internal/domain"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+// minReconnectInterval bounds how often the client is recreated after connection failures, so a
+// capture service that is down does not cause a reconnect on every request.
+const minReconnectInterval = 5 * time.Second
+
 // WeatherService wraps the gRPC client used to capture weather forecasts.
 type WeatherService struct {
-	grpcClient web_capture.WebCaptureServiceClient
-	grpcConn   *grpc.ClientConn
+	grpcAddress string
+	credentials credentials.TransportCredentials
+
+	mu            sync.Mutex
+	grpcClient    web_capture.WebCaptureServiceClient
+	grpcConn      *grpc.ClientConn
+	broken        bool
+	closed        bool
+	lastReconnect time.Time
+	// features caches what the service on the current connection supports; nil until asked.
+	features domain.CaptureFeatures
+}
+
+// WeatherServiceOption customises a WeatherService.
+type WeatherServiceOption func(*WeatherService)
+
+// WithTransportCredentials secures the connection to the capture service, e.g. with TLS. Without
+// it the connection is unencrypted, which suits a capture service on the same host.
+func WithTransportCredentials(creds credentials.TransportCredentials) WeatherServiceOption {
+	return func(ws *WeatherService) {
+		if creds != nil {
+			ws.credentials = creds
+		}
+	}
 }
 
 // NewWeatherService connects to the remote capture service and returns a usable client wrapper.
-func NewWeatherService(grpcAddress string) (*WeatherService, error) {
-	conn, err := grpc.NewClient(
-		grpcAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+func NewWeatherService(grpcAddress string, opts ...WeatherServiceOption) (*WeatherService, error) {
+	ws := &WeatherService{
+		grpcAddress: grpcAddress,
+		credentials: insecure.NewCredentials(),
+	}
+	for _, opt := range opts {
+		opt(ws)
+	}
+
+	conn, err := dialCaptureService(grpcAddress, ws.credentials)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+		return nil, err
 	}
+	ws.grpcClient = web_capture.NewWebCaptureServiceClient(conn)
+	ws.grpcConn = conn
 
-	client := web_capture.NewWebCaptureServiceClient(conn)
+	return ws, nil
+}
 
-	return &WeatherService{
-		grpcClient: client,
-		grpcConn:   conn,
-	}, nil
+func dialCaptureService(
+	grpcAddress string,
+	creds credentials.TransportCredentials,
+) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+	return conn, nil
 }
 
 // Close tears down the underlying gRPC connection.
 func (ws *WeatherService) Close() error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.closed = true
 	if ws.grpcConn != nil {
 		return ws.grpcConn.Close()
 	}
 	return nil
 }
 
-// CaptureWeatherForecast captures the requested element and returns the rendered binary contents.
+// Ping reports whether the capture service is reachable. Clients from grpc.NewClient connect
+// lazily and stay IDLE until the first RPC, so Ping explicitly starts connecting and waits until
+// the connection is READY or has failed, bounded by ctx.
+func (ws *WeatherService) Ping(ctx context.Context) error {
+	ws.client()
+
+	ws.mu.Lock()
+	conn := ws.grpcConn
+	ws.mu.Unlock()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure:
+			return fmt.Errorf("capture service at %s is unreachable", ws.grpcAddress)
+		case connectivity.Shutdown:
+			return fmt.Errorf("capture service connection is closed")
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf(
+				"capture service at %s is not ready (%s): %w",
+				ws.grpcAddress,
+				state,
+				ctx.Err(),
+			)
+		}
+	}
+}
+
+// client returns the current gRPC client, first recreating the connection when an earlier call
+// failed at the connection level and the reconnect interval has elapsed.
+func (ws *WeatherService) client() web_capture.WebCaptureServiceClient {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if ws.closed || !ws.broken || time.Since(ws.lastReconnect) < minReconnectInterval {
+		return ws.grpcClient
+	}
+	ws.lastReconnect = time.Now()
+
+	conn, err := dialCaptureService(ws.grpcAddress, ws.credentials)
+	if err != nil {
+		slog.Warn("failed to reconnect to capture service", slog.Any("error", err))
+		return ws.grpcClient
+	}
+
+	if ws.grpcConn != nil {
+		if err := ws.grpcConn.Close(); err != nil {
+			slog.Warn("failed to close stale capture connection", slog.Any("error", err))
+		}
+	}
+	ws.grpcConn = conn
+	ws.grpcClient = web_capture.NewWebCaptureServiceClient(conn)
+	ws.broken = false
+	// The service may have been replaced by another version while it was unreachable.
+	ws.features = nil
+	slog.Info("reconnected to capture service", slog.String("address", ws.grpcAddress))
+
+	return ws.grpcClient
+}
+
+// observe marks the connection for recreation when err indicates the transport failed, as
+// opposed to the capture itself being rejected.
+func (ws *WeatherService) observe(err error) {
+	if status.Code(err) != codes.Unavailable {
+		return
+	}
+
+	ws.mu.Lock()
+	ws.broken = true
+	ws.mu.Unlock()
+}
+
+// captureFeatures maps the capabilities reported by the capture service to domain features.
+var captureFeatures = map[web_capture.Capability]domain.CaptureFeature{
+	web_capture.Capability_CAPABILITY_HEADERS:         domain.CaptureFeatureHeaders,
+	web_capture.Capability_CAPABILITY_TIMEZONE:        domain.CaptureFeatureTimezone,
+	web_capture.Capability_CAPABILITY_CLIP:            domain.CaptureFeatureClip,
+	web_capture.Capability_CAPABILITY_MATCH_INDEX:     domain.CaptureFeatureMatchIndex,
+	web_capture.Capability_CAPABILITY_QUALITY:         domain.CaptureFeatureQuality,
+	web_capture.Capability_CAPABILITY_FULL_PAGE:       domain.CaptureFeatureFullPage,
+	web_capture.Capability_CAPABILITY_VIEWPORT:        domain.CaptureFeatureViewport,
+	web_capture.Capability_CAPABILITY_SCALE_FACTOR:    domain.CaptureFeatureScaleFactor,
+	web_capture.Capability_CAPABILITY_RENDER_DOCUMENT: domain.CaptureFeatureRenderDocument,
+	web_capture.Capability_CAPABILITY_EXTRACT_TEXT:    domain.CaptureFeatureTextExtraction,
+}
+
+// CaptureFeatures returns the optional features the capture service supports, asking it once per
+// connection. Services predating GetCapabilities support none of them.
+func (ws *WeatherService) CaptureFeatures(ctx context.Context) (domain.CaptureFeatures, error) {
+	client := ws.client()
+
+	ws.mu.Lock()
+	features := ws.features
+	ws.mu.Unlock()
+	if features != nil {
+		return features, nil
+	}
+
+	resp, err := client.GetCapabilities(ctx, &web_capture.GetCapabilitiesRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		features = domain.CaptureFeatures{}
+	case err != nil:
+		ws.observe(err)
+		return nil, fmt.Errorf("failed to query capture service capabilities: %w", err)
+	default:
+		features = make(domain.CaptureFeatures, len(resp.Capabilities))
+		for _, capability := range resp.Capabilities {
+			if feature, ok := captureFeatures[capability]; ok {
+				features[feature] = true
+			}
+		}
+	}
+
+	ws.mu.Lock()
+	if ws.grpcClient == client {
+		ws.features = features
+	}
+	ws.mu.Unlock()
+	return features, nil
+}
+
+// checkFeatures returns a *domain.UnsupportedCaptureError when the capture service lacks any of
+// required. When the service cannot be asked the request is let through, and fails or succeeds
+// on its own.
+func (ws *WeatherService) checkFeatures(
+	ctx context.Context,
+	required ...domain.CaptureFeature,
+) error {
+	if len(required) == 0 {
+		return nil
+	}
+	features, err := ws.CaptureFeatures(ctx)
+	if err != nil {
+		return nil
+	}
+	return features.Check(required)
+}
+
+// CaptureWeatherForecast captures the requested element, or the whole page when no selector or
+// region is given, and returns the rendered binary contents in the requested format. PDF output,
+// and JPEG output of flattened captures, is produced locally from a PNG capture. Requests using
+// options the service does not support fail with a *domain.UnsupportedCaptureError.
 func (ws *WeatherService) CaptureWeatherForecast(
 	ctx context.Context,
-	url, elementSelector string,
+	req domain.CaptureRequest,
 ) ([]byte, error) {
-	req := &web_capture.CaptureElementRequest{
-		Url:             url,
-		ElementSelector: elementSelector,
-		ImageFormat:     web_capture.ImageFormat_IMAGE_FORMAT_PNG,
+	if err := ws.checkFeatures(ctx, req.Features()...); err != nil {
+		return nil, err
+	}
+
+	quality := domain.QualityOrDefault(req.Quality)
+	grpcReq := &web_capture.CaptureElementRequest{
+		Url:             req.URL,
+		ElementSelector: req.ElementSelector,
+		ImageFormat:     captureFormat(req.Format),
+		MatchIndex:      int32(req.MatchIndex),
+		Quality:         int32(quality),
+		FullPage:        req.FullPage(),
+		ViewportWidth:   int32(req.Viewport.Width),
+		ViewportHeight:  int32(req.Viewport.Height),
+		ScaleFactor:     req.Viewport.Scale,
+	}
+	if req.Background != "" {
+		grpcReq.ImageFormat = web_capture.ImageFormat_IMAGE_FORMAT_PNG
+	}
+	if req.Language != "" {
+		grpcReq.Headers = map[string]string{"Accept-Language": req.Language}
+	}
+	grpcReq.TimezoneId = req.Timezone
+	if !req.Region.IsZero() {
+		grpcReq.Clip = &web_capture.ClipRegion{
+			X:      int32(req.Region.X),
+			Y:      int32(req.Region.Y),
+			Width:  int32(req.Region.Width),
+			Height: int32(req.Region.Height),
+		}
 	}
 
-	resp, err := ws.grpcClient.CaptureElement(ctx, req)
+	resp, err := ws.client().CaptureElement(ctx, grpcReq)
 	if err != nil {
+		ws.observe(err)
 		return nil, fmt.Errorf("failed to capture weather forecast: %w", err)
 	}
 
-	return resp.ImageData, nil
+	imageData := resp.ImageData
+	if req.Background != "" {
+		imageData, err = flattenPNG(imageData, req.Background)
+		if err != nil {
+			return nil, fmt.Errorf("failed to flatten forecast: %w", err)
+		}
+		if req.Format == domain.FormatJPEG {
+			return encodeJPEG(imageData, quality)
+		}
+	}
+
+	return convertCapture(imageData, req.Format)
+}
+
+// RenderDocument renders an HTML document through the capture service and returns it in the
+// requested format. An empty selector captures the whole document.
+func (ws *WeatherService) RenderDocument(
+	ctx context.Context,
+	document string,
+	selector string,
+	format domain.Format,
+) ([]byte, error) {
+	if err := ws.checkFeatures(ctx, domain.CaptureFeatureRenderDocument); err != nil {
+		return nil, err
+	}
+
+	resp, err := ws.client().RenderDocument(ctx, &web_capture.RenderDocumentRequest{
+		Html:            document,
+		ElementSelector: selector,
+		ImageFormat:     captureFormat(format),
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, &domain.UnsupportedCaptureError{
+				Features: []domain.CaptureFeature{domain.CaptureFeatureRenderDocument},
+			}
+		}
+		ws.observe(err)
+		return nil, fmt.Errorf("failed to render forecast document: %w", err)
+	}
+
+	return convertCapture(resp.ImageData, format)
+}
+
+// ExtractText returns the rendered text of the element described by req. Services that do not
+// implement text extraction yield domain.ErrTextExtractionUnsupported.
+func (ws *WeatherService) ExtractText(
+	ctx context.Context,
+	req domain.CaptureRequest,
+) (string, error) {
+	if ws.checkFeatures(ctx, domain.CaptureFeatureTextExtraction) != nil {
+		return "", domain.ErrTextExtractionUnsupported
+	}
+
+	grpcReq := &web_capture.ExtractTextRequest{
+		Url:             req.URL,
+		ElementSelector: req.ElementSelector,
+		TimezoneId:      req.Timezone,
+		MatchIndex:      int32(req.MatchIndex),
+	}
+	if req.Language != "" {
+		grpcReq.Headers = map[string]string{"Accept-Language": req.Language}
+	}
+
+	resp, err := ws.client().ExtractText(ctx, grpcReq)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return "", domain.ErrTextExtractionUnsupported
+		}
+		ws.observe(err)
+		return "", fmt.Errorf("failed to extract forecast text: %w", err)
+	}
+
+	return resp.Text, nil
+}
+
+// captureFormat returns the image format requested from the capture service for a delivery
+// format. PDF documents are rendered locally from a PNG capture.
+func captureFormat(format domain.Format) web_capture.ImageFormat {
+	switch format.OrDefault() {
+	case domain.FormatJPEG:
+		return web_capture.ImageFormat_IMAGE_FORMAT_JPEG
+	case domain.FormatWebP:
+		return web_capture.ImageFormat_IMAGE_FORMAT_WEBP
+	default:
+		return web_capture.ImageFormat_IMAGE_FORMAT_PNG
+	}
+}
+
+// convertCapture turns a capture in captureFormat(format) into the requested delivery format.
+func convertCapture(imageData []byte, format domain.Format) ([]byte, error) {
+	if format.OrDefault() == domain.FormatPDF {
+		document, err := renderPDF(imageData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render forecast as pdf: %w", err)
+		}
+		return document, nil
+	}
+
+	return imageData, nil
 }
@@ -0,0 +1,245 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	web_capture "github.com/sglre6355/weather-lady/gen/web_capture/v1"
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCaptureServer is an in-process capture service. It records capture requests and answers
+// them with a fixed image.
+type fakeCaptureServer struct {
+	web_capture.UnimplementedWebCaptureServiceServer
+
+	// capabilities is reported by GetCapabilities; nil leaves the RPC unimplemented, as in
+	// services predating it.
+	capabilities []web_capture.Capability
+
+	mu       sync.Mutex
+	captures []*web_capture.CaptureElementRequest
+}
+
+func (s *fakeCaptureServer) GetCapabilities(
+	ctx context.Context,
+	req *web_capture.GetCapabilitiesRequest,
+) (*web_capture.GetCapabilitiesResponse, error) {
+	if s.capabilities == nil {
+		return s.UnimplementedWebCaptureServiceServer.GetCapabilities(ctx, req)
+	}
+	return &web_capture.GetCapabilitiesResponse{Capabilities: s.capabilities}, nil
+}
+
+func (s *fakeCaptureServer) CaptureElement(
+	_ context.Context,
+	req *web_capture.CaptureElementRequest,
+) (*web_capture.CaptureElementResponse, error) {
+	s.mu.Lock()
+	s.captures = append(s.captures, req)
+	s.mu.Unlock()
+
+	return &web_capture.CaptureElementResponse{
+		ImageFormat: web_capture.ImageFormat_IMAGE_FORMAT_PNG,
+		ImageData:   []byte("forecast"),
+	}, nil
+}
+
+// Captures returns the capture requests received so far.
+func (s *fakeCaptureServer) Captures() []*web_capture.CaptureElementRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*web_capture.CaptureElementRequest(nil), s.captures...)
+}
+
+// serveCapture serves server on address, or on a free local port when address is empty, until
+// the returned stop function is called or the test ends. It returns the address served.
+func serveCapture(
+	t *testing.T,
+	server web_capture.WebCaptureServiceServer,
+	address string,
+) (string, func()) {
+	t.Helper()
+
+	if address == "" {
+		address = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	web_capture.RegisterWebCaptureServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	var once sync.Once
+	stop := func() { once.Do(grpcServer.Stop) }
+	t.Cleanup(stop)
+	return listener.Addr().String(), stop
+}
+
+// newTestWeatherService returns a WeatherService connected to server.
+func newTestWeatherService(
+	t *testing.T,
+	server web_capture.WebCaptureServiceServer,
+) *WeatherService {
+	t.Helper()
+
+	address, _ := serveCapture(t, server, "")
+	ws, err := NewWeatherService(address)
+	if err != nil {
+		t.Fatalf("NewWeatherService: %v", err)
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+	return ws
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestCaptureFeatures(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []web_capture.Capability
+		want         domain.CaptureFeatures
+	}{
+		{
+			name: "service predating GetCapabilities",
+			want: domain.CaptureFeatures{},
+		},
+		{
+			name: "reported capabilities",
+			capabilities: []web_capture.Capability{
+				web_capture.Capability_CAPABILITY_HEADERS,
+				web_capture.Capability_CAPABILITY_CLIP,
+				web_capture.Capability_CAPABILITY_EXTRACT_TEXT,
+				web_capture.Capability(99),
+			},
+			want: domain.CaptureFeatures{
+				domain.CaptureFeatureHeaders:        true,
+				domain.CaptureFeatureClip:           true,
+				domain.CaptureFeatureTextExtraction: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := newTestWeatherService(t, &fakeCaptureServer{capabilities: tt.capabilities})
+
+			got, err := ws.CaptureFeatures(testContext(t))
+			if err != nil {
+				t.Fatalf("CaptureFeatures: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("CaptureFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureWeatherForecastRejectsUnsupportedOptions(t *testing.T) {
+	server := &fakeCaptureServer{
+		capabilities: []web_capture.Capability{web_capture.Capability_CAPABILITY_HEADERS},
+	}
+	ws := newTestWeatherService(t, server)
+	ctx := testContext(t)
+
+	_, err := ws.CaptureWeatherForecast(ctx, domain.CaptureRequest{
+		URL:             "https://example.com/forecast",
+		ElementSelector: "#forecast",
+		Language:        "ja",
+		Timezone:        "Asia/Tokyo",
+	})
+	var unsupported *domain.UnsupportedCaptureError
+	if !errors.As(err, &unsupported) || unsupported.Options() != "timezone" {
+		t.Fatalf("capture with a timezone = %v, want the timezone rejected", err)
+	}
+	if captures := server.Captures(); len(captures) != 0 {
+		t.Fatalf("rejected capture reached the service: %v", captures)
+	}
+
+	_, err = ws.CaptureWeatherForecast(ctx, domain.CaptureRequest{
+		URL:             "https://example.com/forecast",
+		ElementSelector: "#forecast",
+		Language:        "ja",
+	})
+	if err != nil {
+		t.Fatalf("capture with a language: %v", err)
+	}
+	captures := server.Captures()
+	if len(captures) != 1 || captures[0].Headers["Accept-Language"] != "ja" {
+		t.Fatalf("captures = %v, want one sending Accept-Language: ja", captures)
+	}
+}
+
+func TestOptionalRPCsOfOldServices(t *testing.T) {
+	ws := newTestWeatherService(t, &fakeCaptureServer{})
+	ctx := testContext(t)
+
+	_, err := ws.RenderDocument(ctx, "<html></html>", "", domain.FormatPNG)
+	var unsupported *domain.UnsupportedCaptureError
+	if !errors.As(err, &unsupported) || unsupported.Options() != "framed" {
+		t.Errorf("RenderDocument = %v, want framing rejected", err)
+	}
+
+	_, err = ws.ExtractText(ctx, domain.CaptureRequest{URL: "https://example.com/forecast"})
+	if !errors.Is(err, domain.ErrTextExtractionUnsupported) {
+		t.Errorf("ExtractText = %v, want %v", err, domain.ErrTextExtractionUnsupported)
+	}
+}
+
+func TestWeatherServiceReconnectsAfterDrop(t *testing.T) {
+	server := &fakeCaptureServer{}
+	address, stop := serveCapture(t, server, "")
+	ws, err := NewWeatherService(address)
+	if err != nil {
+		t.Fatalf("NewWeatherService: %v", err)
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+
+	ctx := testContext(t)
+	req := domain.CaptureRequest{URL: "https://example.com/forecast", ElementSelector: "#forecast"}
+	if _, err := ws.CaptureWeatherForecast(ctx, req); err != nil {
+		t.Fatalf("capture before the drop: %v", err)
+	}
+	original := ws.grpcConn
+
+	stop()
+	if _, err := ws.CaptureWeatherForecast(ctx, req); err == nil {
+		t.Fatal("capture while the service is down succeeded")
+	}
+	serveCapture(t, server, address)
+
+	if _, err := ws.CaptureWeatherForecast(ctx, req); err != nil {
+		t.Fatalf("capture after the service returned: %v", err)
+	}
+	if ws.grpcConn == original {
+		t.Error("the connection was not recreated after the drop")
+	}
+	if got := len(server.Captures()); got != 2 {
+		t.Errorf("service received %d captures, want 2", got)
+	}
+
+	// A further failure within minReconnectInterval keeps the fresh connection.
+	reconnected := ws.grpcConn
+	ws.observe(status.Error(codes.Unavailable, "connection reset"))
+	ws.client()
+	if ws.grpcConn != reconnected {
+		t.Error("the connection was recreated again within the reconnect interval")
+	}
+}
@@ -0,0 +1,191 @@
+package presentation
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// Discord's limits on autocomplete results: how many choices, and how long each may be.
+const (
+	maxAutocompleteChoices = 25
+	maxChoiceLength        = 100
+)
+
+// commonTimezones are suggested for timezone options, roughly ordered by how many users they
+// cover.
+var commonTimezones = []string{
+	"Asia/Tokyo",
+	"UTC",
+	"America/New_York",
+	"America/Chicago",
+	"America/Denver",
+	"America/Los_Angeles",
+	"America/Sao_Paulo",
+	"America/Toronto",
+	"Europe/London",
+	"Europe/Paris",
+	"Europe/Berlin",
+	"Europe/Madrid",
+	"Europe/Moscow",
+	"Africa/Cairo",
+	"Africa/Johannesburg",
+	"Asia/Dubai",
+	"Asia/Kolkata",
+	"Asia/Bangkok",
+	"Asia/Singapore",
+	"Asia/Shanghai",
+	"Asia/Hong_Kong",
+	"Asia/Taipei",
+	"Asia/Seoul",
+	"Asia/Manila",
+	"Asia/Jakarta",
+	"Australia/Sydney",
+	"Australia/Perth",
+	"Pacific/Auckland",
+	"Pacific/Honolulu",
+}
+
+// handleAutocomplete suggests values for the option the user is typing. Options without
+// suggestions get an empty list, which leaves free text input untouched.
+func (b *WeatherBot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, option := range i.ApplicationCommandData().Options {
+		if !option.Focused {
+			continue
+		}
+		switch option.Name {
+		case "time":
+			choices = timeSuggestions(option.StringValue())
+		case "timezone":
+			choices = timezoneSuggestions(option.StringValue())
+		case "format":
+			choices = formatSuggestions(option.StringValue())
+		}
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	}); err != nil {
+		slog.Error("failed to respond to autocomplete", "error", err)
+	}
+}
+
+// timeSuggestions completes the last of the comma-separated times in typed with the hours and
+// half hours that start with it, keeping the times already entered. With nothing typed yet the
+// list starts at morning times.
+func timeSuggestions(typed string) []*discordgo.ApplicationCommandOptionChoice {
+	entered, current := "", strings.TrimSpace(typed)
+	if index := strings.LastIndex(typed, ","); index >= 0 {
+		entered = typed[:index+1]
+		current = strings.TrimSpace(typed[index+1:])
+	}
+
+	first := 0
+	if current == "" {
+		first = 6 * 60
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for slot := 0; slot < 48 && len(choices) < maxAutocompleteChoices; slot++ {
+		minutes := (first + slot*30) % (24 * 60)
+		candidate := fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+		// Accept "8" for "08:00" as well as "08".
+		if !strings.HasPrefix(candidate, current) && !strings.HasPrefix(candidate, "0"+current) {
+			continue
+		}
+		value := entered + candidate
+		if len(value) > maxChoiceLength {
+			break
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  value,
+			Value: value,
+		})
+	}
+
+	return choices
+}
+
+// formatSuggestion is a format value suggested while typing, with the name shown for it.
+type formatSuggestion struct {
+	value string
+	name  string
+}
+
+// commonFormats are suggested for format options that accept a quality.
+var commonFormats = []formatSuggestion{
+	{"png", "png: PNG image"},
+	{"jpeg", "jpeg: JPEG image (smaller)"},
+	{"jpeg:75", "jpeg:75: JPEG image at quality 75 (much smaller)"},
+	{"webp", "webp: WebP image (smaller)"},
+	{"webp:75", "webp:75: WebP image at quality 75 (much smaller)"},
+	{"pdf", "pdf: PDF document"},
+}
+
+// formatSuggestions returns the common formats starting with typed, preceded by typed itself when
+// it is a valid format with a quality that is not in the list.
+func formatSuggestions(typed string) []*discordgo.ApplicationCommandOptionChoice {
+	query := strings.ToLower(strings.TrimSpace(typed))
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	known := slices.ContainsFunc(commonFormats, func(format formatSuggestion) bool {
+		return format.value == query
+	})
+	if _, _, err := domain.ParseFormatWithQuality(query); err == nil && !known &&
+		strings.Contains(query, ":") {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  query,
+			Value: query,
+		})
+	}
+	for _, format := range commonFormats {
+		if strings.HasPrefix(format.value, query) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  format.name,
+				Value: format.value,
+			})
+		}
+	}
+
+	return choices
+}
+
+// timezoneSuggestions returns the common zones containing typed, preceded by typed itself when it
+// is a valid zone that is not in the list.
+func timezoneSuggestions(typed string) []*discordgo.ApplicationCommandOptionChoice {
+	typed = strings.TrimSpace(typed)
+	query := strings.ToLower(typed)
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	add := func(zone string) {
+		if len(choices) < maxAutocompleteChoices {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  zone,
+				Value: zone,
+			})
+		}
+	}
+
+	known := slices.ContainsFunc(commonTimezones, func(zone string) bool {
+		return strings.EqualFold(zone, typed)
+	})
+	if !known && len(typed) <= maxChoiceLength && strings.Contains(typed, "/") {
+		if _, err := time.LoadLocation(typed); err == nil {
+			add(typed)
+		}
+	}
+	for _, zone := range commonTimezones {
+		if strings.Contains(strings.ToLower(zone), query) {
+			add(zone)
+		}
+	}
+
+	return choices
+}
@@ -3,28 +3,71 @@ package presentation
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
 )
 
+// maxAttachmentBytes is Discord's upload limit for a single message in servers without boosts.
+const maxAttachmentBytes = 10 << 20
+
 // DiscordForecastSender pushes weather snapshots to a Discord channel.
 type DiscordForecastSender struct {
 	session *discordgo.Session
+	embeds  bool
+	retries sendRetries
+	nowFn   func() time.Time
+}
+
+// DiscordForecastSenderOption customises a DiscordForecastSender.
+type DiscordForecastSenderOption func(*DiscordForecastSender)
+
+// WithEmbeds presents image deliveries as embeds with a title, a link to the source page and a
+// capture timestamp, instead of bare attachments. PDF deliveries are always sent as files.
+func WithEmbeds(enabled bool) DiscordForecastSenderOption {
+	return func(s *DiscordForecastSender) {
+		s.embeds = enabled
+	}
+}
+
+// WithSendRetries makes forecast posts that fail with a transient Discord error (a 5xx response or
+// a rate limit) try up to attempts times in total. Retries wait baseDelay, doubling after each,
+// or as long as a rate limit asks.
+func WithSendRetries(attempts int, baseDelay time.Duration) DiscordForecastSenderOption {
+	return func(s *DiscordForecastSender) {
+		s.retries = s.retries.with(attempts, baseDelay)
+	}
 }
 
 // NewDiscordForecastSender wires a Discord session to the forecast dispatch interface expected by the use case layer.
-func NewDiscordForecastSender(session *discordgo.Session) *DiscordForecastSender {
-	return &DiscordForecastSender{session: session}
+func NewDiscordForecastSender(
+	session *discordgo.Session,
+	opts ...DiscordForecastSenderOption,
+) *DiscordForecastSender {
+	s := &DiscordForecastSender{
+		session: session,
+		retries: defaultSendRetries,
+		nowFn:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-// SendForecast posts the supplied image and message to the target Discord channel.
-func (s *DiscordForecastSender) SendForecast(
-	ctx context.Context,
-	channelID string,
-	imageData []byte,
-	message string,
-) error {
+// SendForecast posts the delivery's captures and message to its Discord channel.
+//
+// Multi-image deliveries are best effort: images that cannot be uploaded are dropped with a note
+// in the message and reported through a *usecase.PartialDeliveryError. The delivery only fails
+// outright when no image can be posted.
+func (s *DiscordForecastSender) SendForecast(ctx context.Context, delivery domain.Delivery) error {
 	if s.session == nil {
 		return fmt.Errorf("discord session is not initialised")
 	}
@@ -33,20 +76,326 @@ func (s *DiscordForecastSender) SendForecast(
 		return err
 	}
 
-	payload := &discordgo.MessageSend{
-		Content: message,
-		Files: []*discordgo.File{
-			{
-				Name:        "weather_forecast.png",
-				ContentType: "image/png",
-				Reader:      bytes.NewReader(imageData),
-			},
+	return sendWithinUploadLimit(
+		withFallbackNote(delivery),
+		"send forecast message",
+		func(delivery domain.Delivery, attachments []attachment) error {
+			return s.retries.do(ctx, func() error { return s.send(ctx, delivery, attachments) })
 		},
+	)
+}
+
+// sendWithinUploadLimit posts delivery through send. Multi-image deliveries leave out images past
+// the upload limit, and then the largest remaining image for as long as Discord rejects the post
+// as too large, reporting the omissions through a *usecase.PartialDeliveryError. action names the
+// post in the error returned when no image fits.
+func sendWithinUploadLimit(
+	delivery domain.Delivery,
+	action string,
+	send func(domain.Delivery, []attachment) error,
+) error {
+	attachments := make([]attachment, 0, len(delivery.Images))
+	for index, imageData := range delivery.Images {
+		attachments = append(attachments, attachment{index: index, data: imageData})
+	}
+
+	if len(attachments) <= 1 {
+		return send(delivery, attachments)
+	}
+
+	var dropped []int
+	attachments, dropped = fitAttachments(attachments)
+	for {
+		if len(attachments) == 0 {
+			return fmt.Errorf(
+				"failed to %s: none of %d images fit the upload limit",
+				action,
+				len(delivery.Images),
+			)
+		}
+
+		err := send(withOmissionNote(delivery, len(dropped)), attachments)
+		if err == nil {
+			break
+		}
+		if !isPayloadTooLarge(err) || len(attachments) == 1 {
+			return err
+		}
+
+		var largest int
+		attachments, largest = dropLargest(attachments)
+		dropped = append(dropped, largest)
+	}
+
+	if len(dropped) > 0 {
+		return &usecase.PartialDeliveryError{Dropped: dropped, Total: len(delivery.Images)}
+	}
+
+	return nil
+}
+
+// sendRetries is how often, and after how long, a forecast post failing with a transient Discord
+// error is tried again.
+type sendRetries struct {
+	attempts  int
+	baseDelay time.Duration
+}
+
+// defaultSendRetries tries every post once.
+var defaultSendRetries = sendRetries{attempts: 1, baseDelay: time.Second}
+
+// with returns r updated with the positive values of attempts and baseDelay.
+func (r sendRetries) with(attempts int, baseDelay time.Duration) sendRetries {
+	if attempts > 0 {
+		r.attempts = attempts
+	}
+	if baseDelay > 0 {
+		r.baseDelay = baseDelay
+	}
+	return r
+}
+
+// do calls send, retrying transient Discord failures up to r.attempts times in total.
+func (r sendRetries) do(ctx context.Context, send func() error) error {
+	delay := r.baseDelay
+	for attempt := 1; ; attempt++ {
+		err := send()
+		if err == nil || attempt >= r.attempts {
+			return err
+		}
+		wait, ok := retryWait(err, delay)
+		if !ok {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// retryWait reports whether err is a transient Discord failure worth retrying and how long to wait
+// first: the rate limit's Retry-After when given, otherwise delay.
+func retryWait(err error, delay time.Duration) (time.Duration, bool) {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > 0 {
+			return rateLimitErr.RetryAfter, true
+		}
+		return delay, true
+	}
+
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Response == nil {
+		return 0, false
+	}
+	switch status := restErr.Response.StatusCode; {
+	case status == http.StatusTooManyRequests:
+		seconds, parseErr := strconv.ParseFloat(restErr.Response.Header.Get("Retry-After"), 64)
+		if parseErr != nil || seconds <= 0 {
+			return delay, true
+		}
+		return time.Duration(seconds * float64(time.Second)), true
+	case status >= http.StatusInternalServerError:
+		return delay, true
+	default:
+		return 0, false
+	}
+}
+
+func (s *DiscordForecastSender) send(
+	ctx context.Context,
+	delivery domain.Delivery,
+	attachments []attachment,
+) error {
+	payload := &discordgo.MessageSend{
+		Content: delivery.Message,
+		Files:   forecastFiles(attachments, len(delivery.Images), delivery.Format),
+	}
+	if s.embeds && delivery.Format.OrDefault() != domain.FormatPDF {
+		payload.Embeds = s.forecastEmbeds(delivery, payload.Files)
+	}
+	if delivery.ReplyToMessageID != "" {
+		// Post standalone rather than failing the delivery if the anchor has been deleted.
+		failIfNotExists := false
+		payload.Reference = &discordgo.MessageReference{
+			MessageID:       delivery.ReplyToMessageID,
+			ChannelID:       delivery.ChannelID,
+			FailIfNotExists: &failIfNotExists,
+		}
 	}
 
-	if _, err := s.session.ChannelMessageSendComplex(channelID, payload); err != nil {
+	if _, err := s.session.ChannelMessageSendComplex(
+		delivery.ChannelID,
+		payload,
+		discordgo.WithContext(ctx),
+	); err != nil {
 		return fmt.Errorf("failed to send forecast message: %w", err)
 	}
 
 	return nil
 }
+
+// forecastEmbeds returns one embed per attached image. The embeds share the source URL, which
+// Discord renders as a single gallery.
+func (s *DiscordForecastSender) forecastEmbeds(
+	delivery domain.Delivery,
+	files []*discordgo.File,
+) []*discordgo.MessageEmbed {
+	capturedAt := delivery.FallbackCapturedAt
+	if capturedAt.IsZero() {
+		capturedAt = s.nowFn()
+	}
+
+	embeds := make([]*discordgo.MessageEmbed, 0, len(files))
+	for _, file := range files {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:     "Weather forecast",
+			URL:       delivery.SourceURL,
+			Image:     &discordgo.MessageEmbedImage{URL: "attachment://" + file.Name},
+			Footer:    &discordgo.MessageEmbedFooter{Text: "Captured"},
+			Timestamp: capturedAt.Format(time.RFC3339),
+		})
+	}
+
+	return embeds
+}
+
+// attachment is an image together with its position in the original delivery.
+type attachment struct {
+	index int
+	data  []byte
+}
+
+// fitAttachments drops empty images and, in order, any image that would push the message past
+// the upload limit. It returns the images to send and the indices of those dropped.
+func fitAttachments(attachments []attachment) ([]attachment, []int) {
+	var (
+		kept    []attachment
+		dropped []int
+		total   int
+	)
+	for _, candidate := range attachments {
+		if len(candidate.data) == 0 || total+len(candidate.data) > maxAttachmentBytes {
+			dropped = append(dropped, candidate.index)
+			continue
+		}
+		kept = append(kept, candidate)
+		total += len(candidate.data)
+	}
+
+	return kept, dropped
+}
+
+// dropLargest removes the biggest attachment and returns the remainder and the removed index.
+func dropLargest(attachments []attachment) ([]attachment, int) {
+	largest := 0
+	for position, candidate := range attachments {
+		if len(candidate.data) > len(attachments[largest].data) {
+			largest = position
+		}
+	}
+
+	index := attachments[largest].index
+	remaining := append(attachments[:largest:largest], attachments[largest+1:]...)
+	return remaining, index
+}
+
+func withOmissionNote(delivery domain.Delivery, omitted int) domain.Delivery {
+	if omitted == 0 {
+		return delivery
+	}
+
+	delivery.Message = fmt.Sprintf(
+		"%s\n-# %d of %d images could not be uploaded and were omitted.",
+		delivery.Message,
+		omitted,
+		len(delivery.Images),
+	)
+	return delivery
+}
+
+func withFallbackNote(delivery domain.Delivery) domain.Delivery {
+	if delivery.FallbackCapturedAt.IsZero() {
+		return delivery
+	}
+
+	delivery.Message = fmt.Sprintf(
+		"%s\n-# A fresh capture failed; this forecast was captured <t:%d:R>.",
+		delivery.Message,
+		delivery.FallbackCapturedAt.Unix(),
+	)
+	return delivery
+}
+
+func isPayloadTooLarge(err error) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) &&
+		restErr.Response != nil &&
+		restErr.Response.StatusCode == http.StatusRequestEntityTooLarge
+}
+
+// forecastFiles builds one attachment per image, numbering file names by their position in the
+// original delivery when it had more than one image.
+func forecastFiles(attachments []attachment, total int, format domain.Format) []*discordgo.File {
+	files := make([]*discordgo.File, 0, len(attachments))
+	for _, item := range attachments {
+		name := format.FileName()
+		if total > 1 {
+			name = fmt.Sprintf("%d_%s", item.index+1, name)
+		}
+		files = append(files, &discordgo.File{
+			Name:        name,
+			ContentType: format.ContentType(),
+			Reader:      bytes.NewReader(item.data),
+		})
+	}
+
+	return files
+}
+
+// NotifyUser sends message to userID as a direct message.
+func (s *DiscordForecastSender) NotifyUser(ctx context.Context, userID, message string) error {
+	if s.session == nil {
+		return fmt.Errorf("discord session is not initialised")
+	}
+
+	channel, err := s.session.UserChannelCreate(userID, discordgo.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to open direct message channel: %w", err)
+	}
+	if _, err := s.session.ChannelMessageSend(
+		channel.ID,
+		message,
+		discordgo.WithContext(ctx),
+	); err != nil {
+		return fmt.Errorf("failed to send direct message: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyChannel posts message to channelID without mentioning anyone.
+func (s *DiscordForecastSender) NotifyChannel(
+	ctx context.Context,
+	channelID string,
+	message string,
+) error {
+	if s.session == nil {
+		return fmt.Errorf("discord session is not initialised")
+	}
+
+	if _, err := s.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:         message,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}, discordgo.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to send channel notice: %w", err)
+	}
+
+	return nil
+}
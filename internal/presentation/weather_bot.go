@@ -3,13 +3,22 @@ package presentation
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image/png"
 	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/gorilla/websocket"
 	"github.com/sglre6355/weather-lady/internal/domain"
 	"github.com/sglre6355/weather-lady/internal/usecase"
 )
@@ -20,11 +29,156 @@ const (
 	latestForecastURL       = "https://tenki.jp/"
 )
 
+const welcomeMessage = "Hello! ☀️ I can post daily weather forecasts to your channels.\n" +
+	"Use `/subscribe time:08:00 message:Good morning!` in a channel to get started, " +
+	"`/list-subscriptions` to see what is configured, and `/unsubscribe` to stop deliveries."
+
+var (
+	minIntervalHours        = domain.MinimumInterval.Hours()
+	minStalenessHours       = 1.0
+	minSubscriptionID       = 1.0
+	minListIndex            = 1.0
+	minStartDelayMinutes    = 0.0
+	minMatchIndex           = 0.0
+	minTimeoutSeconds       = 1.0
+	manageGuildPermission   = int64(discordgo.PermissionManageGuild)
+	administratorPermission = int64(discordgo.PermissionAdministrator)
+)
+
+const maxIntervalHours = 24
+
+const maxTimeoutSeconds = float64(domain.MaxCaptureTimeout / time.Second)
+
+const maxOpenRetryDelay = time.Minute
+
+// sessionOpener opens the gateway connection; satisfied by *discordgo.Session.
+type sessionOpener interface {
+	Open() error
+}
+
 // WeatherBot wires Discord events to application use cases.
 type WeatherBot struct {
 	session        *discordgo.Session
+	opener         sessionOpener
 	subscriptions  *usecase.SubscriptionManager
 	weatherCapture usecase.ForecastCapture
+	captureLimiter usecase.CaptureLimiter
+
+	statuses         []string
+	statusRotation   time.Duration
+	presenceOnce     sync.Once
+	stopPresence     chan struct{}
+	stopPresenceOnce sync.Once
+
+	openAttempts   int
+	openRetryDelay time.Duration
+
+	// managePermission is required to add, edit or remove a channel's subscriptions; zero lets
+	// any member do so. Members holding one of manageRoleIDs qualify regardless.
+	managePermission int64
+	manageRoleIDs    []string
+
+	settings     *usecase.SettingsHolder
+	reloadConfig func() ([]string, error)
+	ownersMu     sync.Mutex
+	ownerIDs     map[string]struct{}
+
+	welcomeEnabled bool
+	// commandGuildID, when set, registers the commands in that guild only.
+	commandGuildID string
+	guildsMu       sync.Mutex
+	knownGuilds    map[string]struct{}
+
+	// ready is set once RegisterCommands has finished. Interactions arriving earlier may target
+	// commands that are being recreated, so they are turned away.
+	ready atomic.Bool
+}
+
+// WeatherBotOption configures optional behaviour of the bot.
+type WeatherBotOption func(*WeatherBot)
+
+// WithPresence sets the "Watching" statuses shown by the bot. When more than one status is given,
+// the bot cycles through them every rotation interval.
+func WithPresence(statuses []string, rotation time.Duration) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.statuses = nil
+		for _, status := range statuses {
+			if trimmed := strings.TrimSpace(status); trimmed != "" {
+				b.statuses = append(b.statuses, trimmed)
+			}
+		}
+		if rotation > 0 {
+			b.statusRotation = rotation
+		}
+	}
+}
+
+// WithWelcomeMessage enables posting an introduction to a guild's system channel when the bot joins.
+func WithWelcomeMessage(enabled bool) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.welcomeEnabled = enabled
+	}
+}
+
+// WithCommandGuild registers the bot's commands in guildID instead of globally. Guild commands
+// update instantly, which suits development; global commands can take an hour to propagate.
+func WithCommandGuild(guildID string) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.commandGuildID = strings.TrimSpace(guildID)
+	}
+}
+
+// WithOpenRetry retries opening the Discord session up to attempts times, doubling delay between
+// attempts. Authentication and configuration failures are never retried.
+func WithOpenRetry(attempts int, delay time.Duration) WeatherBotOption {
+	return func(b *WeatherBot) {
+		if attempts > 0 {
+			b.openAttempts = attempts
+		}
+		if delay > 0 {
+			b.openRetryDelay = delay
+		}
+	}
+}
+
+// WithSettings makes the bot read default forecast sources from holder, so reloaded values apply
+// to the next command.
+func WithSettings(holder *usecase.SettingsHolder) WeatherBotOption {
+	return func(b *WeatherBot) {
+		if holder != nil {
+			b.settings = holder
+		}
+	}
+}
+
+// WithCaptureLimiter makes /latest-forecast draw on the guild's capture allowance.
+func WithCaptureLimiter(limiter usecase.CaptureLimiter) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.captureLimiter = limiter
+	}
+}
+
+// WithConfigReloader enables /admin-reload-config. reload re-reads configuration, applies it and
+// returns the names of the settings that changed.
+func WithConfigReloader(reload func() ([]string, error)) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.reloadConfig = reload
+	}
+}
+
+// WithSubscriptionPermission sets the permission members need to add, edit or remove a channel's
+// subscriptions (Manage Channels by default), and roles whose members may do so without it. A
+// zero permission allows every member.
+func WithSubscriptionPermission(permission int64, roleIDs []string) WeatherBotOption {
+	return func(b *WeatherBot) {
+		b.managePermission = permission
+		b.manageRoleIDs = nil
+		for _, roleID := range roleIDs {
+			if trimmed := strings.TrimSpace(roleID); trimmed != "" {
+				b.manageRoleIDs = append(b.manageRoleIDs, trimmed)
+			}
+		}
+	}
 }
 
 // NewWeatherBot constructs a bot instance with all supporting services wired up.
@@ -32,6 +186,7 @@ func NewWeatherBot(
 	session *discordgo.Session,
 	subscriptions *usecase.SubscriptionManager,
 	capture usecase.ForecastCapture,
+	opts ...WeatherBotOption,
 ) (*WeatherBot, error) {
 	if session == nil {
 		return nil, fmt.Errorf("discord session cannot be nil")
@@ -44,31 +199,96 @@ func NewWeatherBot(
 	}
 
 	bot := &WeatherBot{
-		session:        session,
-		subscriptions:  subscriptions,
-		weatherCapture: capture,
+		session:          session,
+		opener:           session,
+		subscriptions:    subscriptions,
+		weatherCapture:   capture,
+		openAttempts:     1,
+		openRetryDelay:   time.Second,
+		managePermission: discordgo.PermissionManageChannels,
+		statusRotation:   10 * time.Minute,
+		stopPresence:     make(chan struct{}),
+		knownGuilds:      make(map[string]struct{}),
+		settings: usecase.NewSettingsHolder(usecase.Settings{
+			DefaultForecastURL:      defaultForecastURL,
+			DefaultForecastSelector: defaultForecastSelector,
+			LatestForecastURL:       latestForecastURL,
+		}),
+	}
+
+	for _, opt := range opts {
+		opt(bot)
 	}
 
 	session.AddHandler(bot.onReady)
 	session.AddHandler(bot.onInteractionCreate)
+	session.AddHandler(bot.onGuildCreate)
 
 	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages
 
 	return bot, nil
 }
 
-// Start establishes the connection to Discord.
+// Start establishes the connection to Discord, retrying transient failures with backoff.
 func (b *WeatherBot) Start() error {
-	if err := b.session.Open(); err != nil {
-		return fmt.Errorf("failed to open Discord session: %w", err)
+	delay := b.openRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := b.opener.Open()
+		if err == nil {
+			slog.Info("Bot successfully started")
+			return nil
+		}
+
+		if attempt >= b.openAttempts || !isRetryableOpenError(err) {
+			return fmt.Errorf("failed to open Discord session: %w", err)
+		}
+
+		slog.Warn(
+			"failed to open Discord session, retrying",
+			"attempt",
+			attempt,
+			"retryIn",
+			delay,
+			"error",
+			err,
+		)
+		time.Sleep(delay)
+		delay = min(delay*2, maxOpenRetryDelay)
 	}
+}
 
-	slog.Info("Bot successfully started")
-	return nil
+// isRetryableOpenError reports whether a gateway connection failure may succeed on a later attempt.
+func isRetryableOpenError(err error) bool {
+	if errors.Is(err, discordgo.ErrWSAlreadyOpen) {
+		return false
+	}
+
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		switch closeErr.Code {
+		case 4004, 4010, 4011, 4012, 4013, 4014:
+			// Authentication failed, invalid shard, sharding required, invalid API version,
+			// invalid intents or disallowed intents: retrying cannot help.
+			return false
+		}
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		switch restErr.Response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return false
+		}
+	}
+
+	return true
 }
 
-// Stop releases all resources and stops scheduled deliveries.
+// Stop releases all resources and stops scheduled deliveries. Deliveries in progress are given the
+// manager's shutdown timeout to finish before the session is closed.
 func (b *WeatherBot) Stop() {
+	b.stopPresenceOnce.Do(func() { close(b.stopPresence) })
+
 	if b.subscriptions != nil {
 		b.subscriptions.Shutdown()
 	}
@@ -81,6 +301,12 @@ func (b *WeatherBot) Stop() {
 }
 
 func (b *WeatherBot) onReady(s *discordgo.Session, event *discordgo.Ready) {
+	b.guildsMu.Lock()
+	for _, guild := range event.Guilds {
+		b.knownGuilds[guild.ID] = struct{}{}
+	}
+	b.guildsMu.Unlock()
+
 	slog.Info(
 		"Logged in",
 		"username",
@@ -88,109 +314,717 @@ func (b *WeatherBot) onReady(s *discordgo.Session, event *discordgo.Ready) {
 		"discriminator",
 		s.State.User.Discriminator,
 	)
+
+	if len(b.statuses) == 0 {
+		return
+	}
+
+	b.updatePresence(s, b.statuses[0])
+	if len(b.statuses) > 1 {
+		b.presenceOnce.Do(func() { go b.rotatePresence(s) })
+	}
+}
+
+// onGuildCreate welcomes genuinely new guilds. Discord also emits GuildCreate for every guild
+// listed in Ready while hydrating state at startup; those are recognised and skipped.
+func (b *WeatherBot) onGuildCreate(s *discordgo.Session, event *discordgo.GuildCreate) {
+	if !b.markGuildJoined(event.Guild) || !b.welcomeEnabled {
+		return
+	}
+
+	if event.SystemChannelID == "" {
+		slog.Info("joined guild without a system channel", "guildID", event.ID)
+		return
+	}
+
+	if _, err := s.ChannelMessageSend(event.SystemChannelID, welcomeMessage); err != nil {
+		slog.Error("failed to send welcome message", "guildID", event.ID, "error", err)
+	}
+}
+
+// markGuildJoined records guild as known and reports whether it is a new join rather than
+// startup hydration or a guild recovering from an outage.
+func (b *WeatherBot) markGuildJoined(guild *discordgo.Guild) bool {
+	if guild == nil || guild.Unavailable {
+		return false
+	}
+
+	b.guildsMu.Lock()
+	defer b.guildsMu.Unlock()
+
+	if _, known := b.knownGuilds[guild.ID]; known {
+		return false
+	}
+	b.knownGuilds[guild.ID] = struct{}{}
+
+	return true
+}
+
+// rotatePresence cycles through the configured statuses until the bot is stopped.
+func (b *WeatherBot) rotatePresence(s *discordgo.Session) {
+	ticker := time.NewTicker(b.statusRotation)
+	defer ticker.Stop()
+
+	next := 1
+	for {
+		select {
+		case <-ticker.C:
+			b.updatePresence(s, b.statuses[next])
+			next = (next + 1) % len(b.statuses)
+		case <-b.stopPresence:
+			return
+		}
+	}
+}
+
+func (b *WeatherBot) updatePresence(s *discordgo.Session, status string) {
+	if err := s.UpdateStatusComplex(discordgo.UpdateStatusData{
+		Activities: []*discordgo.Activity{
+			{
+				Name: status,
+				Type: discordgo.ActivityTypeWatching,
+			},
+		},
+		Status: string(discordgo.StatusOnline),
+	}); err != nil {
+		slog.Error("failed to update presence", "error", err)
+	}
 }
 
 func (b *WeatherBot) onInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		b.handleAutocomplete(s, i)
+		return
+	}
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
+	if !b.ready.Load() {
+		b.respondWithError(s, i, "The bot is starting up, please try again in a moment")
+		return
+	}
 
 	switch i.ApplicationCommandData().Name {
+	case "help":
+		b.handleHelp(s, i)
 	case "subscribe":
 		b.handleSubscribeWeather(s, i)
 	case "unsubscribe":
 		b.handleUnsubscribeWeather(s, i)
+	case "mute":
+		b.handleSetPaused(s, i, true)
+	case "resume":
+		b.handleSetPaused(s, i, false)
 	case "latest-forecast":
 		b.handleCurrentWeather(s, i)
+	case "forecast-now":
+		b.handleForecastNow(s, i)
+	case "validate":
+		b.handleValidate(s, i)
+	case "preview":
+		b.handlePreview(s, i)
+	case "next-delivery":
+		b.handleNextDelivery(s, i)
+	case "transfer-subscription":
+		b.handleTransferSubscription(s, i)
+	case "edit-subscription":
+		b.handleEditSubscription(s, i)
 	case "list-subscriptions":
 		b.handleListSubscriptions(s, i)
+	case "guild-usage":
+		b.handleGuildUsage(s, i)
+	case "subscriptions-in-guild":
+		b.listGuildSubscriptions(s, i)
+	case "admin-reload-config":
+		b.handleReloadConfig(s, i)
 	}
 }
 
-// RegisterCommands recreates the slash commands used by the bot.
+// RegisterCommands recreates the slash commands used by the bot, globally or, when a command
+// guild is configured, in that guild only. Commands left over in the other scope are deleted.
+// Interactions are rejected with a "starting up" notice until it succeeds.
 func (b *WeatherBot) RegisterCommands() error {
-	existingCommands, err := b.session.ApplicationCommands(b.session.State.User.ID, "")
+	appID := b.session.State.User.ID
+
+	b.deleteCommands(appID, "")
+	if b.commandGuildID != "" {
+		b.deleteCommands(appID, b.commandGuildID)
+	}
+
+	for _, cmd := range b.commands() {
+		if _, err := b.session.ApplicationCommandCreate(appID, b.commandGuildID, cmd); err != nil {
+			return fmt.Errorf("failed to create command %s: %w", cmd.Name, err)
+		}
+	}
+
+	b.ready.Store(true)
+	return nil
+}
+
+// deleteCommands removes the application's commands registered in guildID, or its global
+// commands when guildID is empty. Failures are logged.
+func (b *WeatherBot) deleteCommands(appID, guildID string) {
+	existingCommands, err := b.session.ApplicationCommands(appID, guildID)
 	if err != nil {
-		slog.Error("failed to get existing commands", "error", err)
+		slog.Error("failed to get existing commands", "guildID", guildID, "error", err)
+		return
+	}
+
+	for _, cmd := range existingCommands {
+		if err := b.session.ApplicationCommandDelete(appID, guildID, cmd.ID); err != nil {
+			slog.Error("failed to delete command", "command", cmd.Name, "error", err)
+		}
+	}
+}
+
+// commandExamples shows typical invocations of the commands in /help.
+var commandExamples = map[string]string{
+	"subscribe":         "/subscribe time:08:00 message:Good morning! days:weekdays",
+	"unsubscribe":       "/unsubscribe index:2",
+	"mute":              "/mute index:1",
+	"latest-forecast":   "/latest-forecast format:jpeg",
+	"preview":           "/preview url:https://tenki.jp/ selector:#forecast-map-wrap",
+	"edit-subscription": "/edit-subscription index:1 time:07:30",
+	"forecast-now":      "/forecast-now id:12",
+}
+
+// Discord's limits on message and embed text.
+const (
+	maxMessageContent   = 2000
+	maxEmbedDescription = 4096
+	maxEmbedFieldValue  = 1024
+	maxEmbedFields      = 25
+	maxEmbedTotal       = 6000
+)
+
+// handleHelp describes every command, or one command's options in detail when the command option
+// is given.
+func (b *WeatherBot) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	commands := b.commands()
+
+	var embed *discordgo.MessageEmbed
+	options := i.ApplicationCommandData().Options
+	if len(options) > 0 && options[0].Name == "command" {
+		name := strings.TrimPrefix(strings.TrimSpace(options[0].StringValue()), "/")
+		index := slices.IndexFunc(commands, func(cmd *discordgo.ApplicationCommand) bool {
+			return cmd.Name == name
+		})
+		if index < 0 {
+			b.respondWithError(s, i, fmt.Sprintf("There is no /%s command. Try /help", name))
+			return
+		}
+		embed = commandHelp(commands[index])
 	} else {
-		for _, cmd := range existingCommands {
-			if err := b.session.ApplicationCommandDelete(b.session.State.User.ID, "", cmd.ID); err != nil {
-				slog.Error("failed to delete command", "command", cmd.Name, "error", err)
+		embed = &discordgo.MessageEmbed{
+			Title:       "Weather Lady commands",
+			Description: "Use `/help command:<name>` for a command's options and an example.",
+		}
+		for _, cmd := range commands {
+			value := cmd.Description
+			if len(cmd.Options) > 0 {
+				names := make([]string, 0, len(cmd.Options))
+				for _, option := range cmd.Options {
+					names = append(names, "`"+option.Name+"`")
+				}
+				value += "\nOptions: " + strings.Join(names, ", ")
 			}
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "/" + cmd.Name,
+				Value: truncate(value, maxEmbedFieldValue),
+			})
+		}
+	}
+
+	embeds := splitEmbed(embed)
+	messages := make([]*discordgo.WebhookParams, 0, len(embeds))
+	for _, page := range embeds {
+		messages = append(messages, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{page},
+		})
+	}
+	b.respondWithMessages(s, i, messages)
+}
+
+// commandHelp describes cmd's options and, when there is one, an example invocation.
+func commandHelp(cmd *discordgo.ApplicationCommand) *discordgo.MessageEmbed {
+	var builder strings.Builder
+	builder.WriteString(cmd.Description)
+	if len(cmd.Options) > 0 {
+		builder.WriteString("\n\n**Options**\n")
+	}
+	for _, option := range cmd.Options {
+		required := ""
+		if option.Required {
+			required = " (required)"
 		}
+		fmt.Fprintf(&builder, "- `%s`%s: %s\n", option.Name, required, option.Description)
+	}
+	if example, ok := commandExamples[cmd.Name]; ok {
+		fmt.Fprintf(&builder, "\n**Example**\n`%s`", example)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "/" + cmd.Name,
+		Description: truncate(builder.String(), maxEmbedDescription),
+	}
+}
+
+// truncate shortens text to at most limit characters, marking the cut with an ellipsis.
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
 	}
+	return string(runes[:limit-1]) + "…"
+}
 
+// commands returns the slash commands offered by the bot. /help is generated from the same list
+// so it always matches what is registered.
+func (b *WeatherBot) commands() []*discordgo.ApplicationCommand {
 	commands := []*discordgo.ApplicationCommand{
+		{
+			Name:        "help",
+			Description: "Describe the bot's commands and their options",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "command",
+					Description: "Command to describe in detail, e.g. subscribe",
+					Required:    false,
+				},
+			},
+		},
 		{
 			Name:        "subscribe",
 			Description: "Subscribe this channel to receive weather forecasts",
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "time",
-					Description: "Time to send weather forecast (format: HH:MM, e.g., 08:00)",
+					Name:        "message",
+					Description: "Forecast message; {date}, {time}, {weekday}, {url} and {source} expand",
 					Required:    true,
+					MaxLength:   domain.MaxMessageLength,
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "message",
-					Description: "Custom message to send with the weather forecast",
-					Required:    true,
+					Name:        "label",
+					Description: "Short name shown in listings, e.g. Kanto morning map",
+					Required:    false,
+					MaxLength:   domain.MaxLabelLength,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "also_post_to",
+					Description: "Other channels that receive the same forecast, e.g. #tokyo #osaka",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reply_to",
+					Description: "ID of a message in this channel that every forecast replies to",
+					Required:    false,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "time",
+					Description:  "Times to send forecasts (HH:MM, optional UTC offset, comma-separated, e.g. 08:00,18:00)",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "days",
+					Description: "Days to deliver on, e.g. mon,wed,fri, weekdays or weekends (default: daily)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "start_delay_minutes",
+					Description: "Instead of a time, first deliver this many minutes from now, then repeat",
+					Required:    false,
+					MinValue:    &minStartDelayMinutes,
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "url",
-					Description: "URL to capture weather data from",
+					Description: "URL to capture weather data from, or latest to follow the latest forecast page",
 					Required:    false,
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionString,
 					Name:        "selector",
-					Description: "CSS selector for the element to capture",
+					Description: "CSS selector for the element to capture, or full-page for the whole page",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "index",
+					Description: "Which element matching the selector to capture, 0 for the first (default: 0)",
+					Required:    false,
+					MinValue:    &minMatchIndex,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "region",
+					Description: "Page area to capture instead of a selector, as x,y,width,height in pixels",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "viewport",
+					Description: "Browser size and scale, e.g. 1280x800 or 1280x800@2 (default: the service's)",
+					Required:    false,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "format",
+					Description:  "png, jpeg, webp or pdf; add :quality (1-100) for jpeg/webp, e.g. jpeg:80 (default: png)",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "background",
+					Description: "Hex color filling transparent areas so any theme can read it, e.g. #ffffff",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "language",
+					Description: "Language to render the forecast page in (default: the site's default)",
+					Required:    false,
+					Choices:     languageChoices(),
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "timezone",
+					Description:  "Your IANA timezone for time and the page, e.g. Asia/Tokyo (default: UTC)",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "forecast_days",
+					Description: "Day offsets posted together, e.g. 0,1,2 ({date}/{offset} expand in url/selector)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "framed",
+					Description: "Wrap the capture with a header and timestamp (default: false)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "interval_hours",
+					Description: "Repeat every this many hours from time instead of daily",
+					Required:    false,
+					MinValue:    &minIntervalHours,
+					MaxValue:    maxIntervalHours,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max_staleness_hours",
+					Description: "Post the last capture if a fresh one fails and it is at most this old",
+					Required:    false,
+					MinValue:    &minStalenessHours,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "timeout_seconds",
+					Description: "Seconds each capture may take, e.g. more for heavy pages (default: the bot's)",
+					Required:    false,
+					MinValue:    &minTimeoutSeconds,
+					MaxValue:    maxTimeoutSeconds,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "confirm_first_delivery",
+					Description: "Send me a direct message once the first forecast has been delivered",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "keywords",
+					Description: "Only deliver when the captured text contains one of these, e.g. rain, storm",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "webhook_url",
+					Description: "Webhook of this channel that posts the forecasts under its own name and avatar",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionChannel,
+					Name:        "error_channel",
+					Description: "Channel that is notified when a delivery of this subscription fails",
 					Required:    false,
+					ChannelTypes: []discordgo.ChannelType{
+						discordgo.ChannelTypeGuildText,
+						discordgo.ChannelTypeGuildNews,
+					},
 				},
 			},
 		},
 		{
 			Name:        "unsubscribe",
 			Description: "Unsubscribe this channel from weather forecasts",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "index",
+					Description: "Number from /list-subscriptions of the only subscription to remove",
+					Required:    false,
+					MinValue:    &minListIndex,
+				},
+			},
 		},
 		{
-			Name:        "latest-forecast",
-			Description: "Show latest weather forecast",
+			Name:        "mute",
+			Description: "Pause this channel's forecasts without removing them, e.g. during a holiday",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "index",
+					Description: "Number from /list-subscriptions of the only subscription to pause",
+					Required:    false,
+					MinValue:    &minListIndex,
+				},
+			},
 		},
 		{
-			Name:        "list-subscriptions",
-			Description: "List all weather subscriptions configured in this server",
+			Name:        "resume",
+			Description: "Resume this channel's paused forecasts",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "index",
+					Description: "Number from /list-subscriptions of the only subscription to resume",
+					Required:    false,
+					MinValue:    &minListIndex,
+				},
+			},
 		},
-	}
-
-	for _, cmd := range commands {
-		if _, err := b.session.ApplicationCommandCreate(b.session.State.User.ID, "", cmd); err != nil {
-			return fmt.Errorf("failed to create command %s: %w", cmd.Name, err)
-		}
-	}
-
-	return nil
-}
-
+		{
+			Name:        "latest-forecast",
+			Description: "Show latest weather forecast",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "format",
+					Description: "File format of the forecast (default: png)",
+					Required:    false,
+					Choices:     formatChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "viewport",
+					Description: "Browser size and scale, e.g. 1280x800 or 1280x800@2 (default: the service's)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "forecast-now",
+			Description: "Post a saved subscription's forecast immediately",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "id",
+					Description: "Subscription ID from /list-subscriptions (default: this channel's only one)",
+					Required:    false,
+					MinValue:    &minSubscriptionID,
+				},
+			},
+		},
+		{
+			Name:        "validate",
+			Description: "Check that a saved subscription can still be captured, without posting it",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "id",
+					Description: "Subscription ID from /list-subscriptions (default: this channel's only one)",
+					Required:    false,
+					MinValue:    &minSubscriptionID,
+				},
+			},
+		},
+		{
+			Name:        "next-delivery",
+			Description: "Show when this channel's forecasts are next delivered",
+		},
+		{
+			Name:        "preview",
+			Description: "Privately capture a page to check a URL and selector before subscribing",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "url",
+					Description: "URL to capture",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "selector",
+					Description: "CSS selector to capture, or full-page (default: the default forecast map)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "edit-subscription",
+			Description: "Change a subscription of this channel, keeping the options you leave out",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "index",
+					Description: "Number of the subscription in /list-subscriptions",
+					Required:    true,
+					MinValue:    &minListIndex,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "time",
+					Description: "New delivery time (HH:MM, optional UTC offset)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "message",
+					Description: "New message sent with the weather forecast",
+					Required:    false,
+					MaxLength:   domain.MaxMessageLength,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "label",
+					Description: "New short name shown in listings",
+					Required:    false,
+					MaxLength:   domain.MaxLabelLength,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "url",
+					Description: "New URL to capture weather data from",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "selector",
+					Description: "New CSS selector for the element to capture, or full-page for the whole page",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "list-subscriptions",
+			Description: "List the weather subscriptions of this channel",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "all_channels",
+					Description: "List every subscription in this server instead (requires Manage Server)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:                     "transfer-subscription",
+			Description:              "Make another member the owner of a subscription",
+			DefaultMemberPermissions: &manageGuildPermission,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "id",
+					Description: "Subscription ID from /list-subscriptions",
+					Required:    true,
+					MinValue:    &minSubscriptionID,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "to",
+					Description: "Member who becomes the owner",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:                     "guild-usage",
+			Description:              "Show how many weather subscriptions this server uses",
+			DefaultMemberPermissions: &manageGuildPermission,
+		},
+		{
+			Name:                     "subscriptions-in-guild",
+			Description:              "List every weather subscription in this server by channel",
+			DefaultMemberPermissions: &manageGuildPermission,
+		},
+	}
+
+	if b.reloadConfig != nil {
+		commands = append(commands, &discordgo.ApplicationCommand{
+			Name:                     "admin-reload-config",
+			Description:              "Reload the bot configuration (bot owner only)",
+			DefaultMemberPermissions: &administratorPermission,
+		})
+	}
+
+	return commands
+}
+
 func (b *WeatherBot) handleSubscribeWeather(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.canManageSubscriptions(i) {
+		b.respondWithError(s, i, "You don't have permission to subscribe this channel")
+		return
+	}
+
 	options := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
 	for _, option := range i.ApplicationCommandData().Options {
 		opt := option
 		options[opt.Name] = opt
 	}
 
-	timeOption, ok := options["time"]
-	if !ok {
-		b.respondWithError(s, i, "Time option is required")
-		return
+	// A start delay, even of zero, schedules from now instead of at a time of day.
+	alignment := domain.AlignToWallClock
+	if _, ok := options["start_delay_minutes"]; ok {
+		alignment = domain.AlignToCreation
 	}
 
-	parsedTime, err := time.Parse("15:04", timeOption.StringValue())
-	if err != nil {
-		b.respondWithError(s, i, "Invalid time format. Please use HH:MM format (e.g., 08:00)")
+	timezone := ""
+	if option, ok := options["timezone"]; ok {
+		parsed, err := domain.ParseTimezone(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		timezone = parsed
+	}
+	now := time.Now().In(domain.Subscription{Timezone: timezone}.Location())
+
+	var (
+		// Creation-aligned subscriptions get their time when added.
+		parsedTimes = []time.Time{{}}
+		startDelay  time.Duration
+	)
+	timeOption, hasTime := options["time"]
+	delayOption, hasDelay := options["start_delay_minutes"]
+	switch {
+	case hasDelay && hasTime:
+		b.respondWithError(s, i, "Please provide either time or start_delay_minutes, not both")
+		return
+	case hasDelay:
+		startDelay = time.Duration(delayOption.IntValue()) * time.Minute
+	case !hasTime:
+		b.respondWithError(s, i, "Time option is required")
 		return
+	default:
+		parsed, err := domain.ParseTimesOfDay(timeOption.StringValue(), now)
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		parsedTimes = parsed
 	}
 
 	messageOption, ok := options["message"]
@@ -199,46 +1033,360 @@ func (b *WeatherBot) handleSubscribeWeather(s *discordgo.Session, i *discordgo.I
 		return
 	}
 
-	url := defaultForecastURL
-	if option, ok := options["url"]; ok && option.StringValue() != "" {
-		url = option.StringValue()
+	settings := b.settings.Load()
+
+	var extraChannels []string
+	if option, ok := options["also_post_to"]; ok {
+		parsed, message := b.parseExtraChannels(s, i, option.StringValue())
+		if message != "" {
+			b.respondWithError(s, i, message)
+			return
+		}
+		extraChannels = parsed
+	}
+
+	webhookURL := ""
+	if option, ok := options["webhook_url"]; ok && option.StringValue() != "" {
+		webhookURL = strings.TrimSpace(option.StringValue())
+		if message := checkWebhook(s, i, webhookURL); message != "" {
+			b.respondWithError(s, i, message)
+			return
+		}
+	}
+
+	errorChannel := ""
+	if option, ok := options["error_channel"]; ok {
+		errorChannel = option.ChannelValue(nil).ID
+		if message := checkChannel(
+			s,
+			i,
+			errorChannel,
+			discordgo.PermissionViewChannel|discordgo.PermissionSendMessages,
+		); message != "" {
+			b.respondWithError(s, i, message)
+			return
+		}
+	}
+
+	label := ""
+	if option, ok := options["label"]; ok {
+		label = strings.TrimSpace(option.StringValue())
 	}
 
-	selector := defaultForecastSelector
+	replyTo := ""
+	if option, ok := options["reply_to"]; ok && strings.TrimSpace(option.StringValue()) != "" {
+		replyTo = strings.TrimSpace(option.StringValue())
+		if _, err := s.ChannelMessage(i.ChannelID, replyTo); err != nil {
+			b.respondWithError(s, i, "The reply_to message could not be found in this channel")
+			return
+		}
+	}
+
+	mode := domain.ForecastModeFixed
+	url := settings.DefaultForecastURL
+	if option, ok := options["url"]; ok && strings.EqualFold(
+		strings.TrimSpace(option.StringValue()),
+		string(domain.ForecastModeLatest),
+	) {
+		mode = domain.ForecastModeLatest
+		url = settings.LatestForecastURL
+	} else if ok && option.StringValue() != "" {
+		parsed, err := domain.ParseSourceURL(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		url = parsed
+	}
+
+	selector := settings.DefaultForecastSelector
 	if option, ok := options["selector"]; ok && option.StringValue() != "" {
-		selector = option.StringValue()
+		selector = domain.ParseSelector(option.StringValue())
+	}
+
+	matchIndex := 0
+	if option, ok := options["index"]; ok {
+		matchIndex = int(option.IntValue())
+	}
+
+	var region domain.Region
+	if option, ok := options["region"]; ok && option.StringValue() != "" {
+		if _, ok := options["selector"]; ok {
+			b.respondWithError(s, i, "Please provide either selector or region, not both")
+			return
+		}
+		parsed, err := domain.ParseRegion(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		if _, ok := options["index"]; ok {
+			b.respondWithError(s, i, "The index option only applies to selector captures")
+			return
+		}
+		region = parsed
+		selector = ""
+	}
+
+	format, quality := domain.FormatPNG, 0
+	if option, ok := options["format"]; ok {
+		parsedFormat, parsedQuality, err := domain.ParseFormatWithQuality(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		format, quality = parsedFormat, parsedQuality
+	}
+
+	background := ""
+	if option, ok := options["background"]; ok {
+		parsed, err := domain.ParseHexColor(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		background = parsed
+	}
+
+	var viewport domain.Viewport
+	if option, ok := options["viewport"]; ok {
+		parsed, err := domain.ParseViewport(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		viewport = parsed
+	}
+
+	language := ""
+	if option, ok := options["language"]; ok {
+		parsed, err := domain.ParseLanguage(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, "Unsupported language")
+			return
+		}
+		language = parsed
+	}
+
+	var forecastDays []int
+	if option, ok := options["forecast_days"]; ok {
+		parsed, err := domain.ParseForecastDays(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		forecastDays = parsed
+	}
+
+	framed := false
+	if option, ok := options["framed"]; ok {
+		framed = option.BoolValue()
+	}
+
+	var days domain.Weekdays
+	if option, ok := options["days"]; ok {
+		parsed, err := domain.ParseWeekdays(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		days = parsed
+	}
+
+	var everyN time.Duration
+	if option, ok := options["interval_hours"]; ok {
+		everyN = time.Duration(option.IntValue()) * time.Hour
+	}
+
+	var maxStaleness time.Duration
+	if option, ok := options["max_staleness_hours"]; ok {
+		maxStaleness = time.Duration(option.IntValue()) * time.Hour
+	}
+
+	var captureTimeout time.Duration
+	if option, ok := options["timeout_seconds"]; ok {
+		captureTimeout = time.Duration(option.IntValue()) * time.Second
+	}
+
+	var keywords []string
+	if option, ok := options["keywords"]; ok {
+		parsed, err := domain.ParseKeywords(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		keywords = parsed
+	}
+
+	confirmFirstDelivery := false
+	if option, ok := options["confirm_first_delivery"]; ok {
+		confirmFirstDelivery = option.BoolValue()
 	}
 
 	sub := domain.Subscription{
-		ChannelID:       i.ChannelID,
-		GuildID:         i.GuildID,
-		Time:            parsedTime,
-		URL:             url,
-		ElementSelector: selector,
-		Message:         messageOption.StringValue(),
+		ChannelID:            i.ChannelID,
+		GuildID:              i.GuildID,
+		ExtraChannelIDs:      extraChannels,
+		Label:                label,
+		CreatedByUserID:      interactionUserID(i),
+		URL:                  url,
+		ElementSelector:      selector,
+		Message:              messageOption.StringValue(),
+		ReplyToMessageID:     replyTo,
+		MatchIndex:           matchIndex,
+		Region:               region,
+		Viewport:             viewport,
+		Alignment:            alignment,
+		StartDelay:           startDelay,
+		Days:                 days,
+		EveryN:               everyN,
+		Format:               format,
+		Quality:              quality,
+		Background:           background,
+		Language:             language,
+		Timezone:             timezone,
+		ForecastDays:         forecastDays,
+		Framed:               framed,
+		Mode:                 mode,
+		MaxStaleness:         maxStaleness,
+		CaptureTimeout:       captureTimeout,
+		ConfirmFirstDelivery: confirmFirstDelivery,
+		ErrorNotifyChannelID: errorChannel,
+		WebhookURL:           webhookURL,
+		Keywords:             keywords,
 	}
 
-	if err := b.subscriptions.Add(sub); err != nil {
-		slog.Error("failed to add subscription for channel", "channelID", i.ChannelID, "error", err)
-		b.respondWithError(s, i, "Failed to subscribe channel to weather forecasts")
+	if err := sub.Validate(); err != nil {
+		b.respondWithError(s, i, validationMessage(err))
 		return
 	}
 
+	// Each time becomes its own subscription so it can be listed, edited and removed on its own.
+	schedules := make([]string, 0, len(parsedTimes))
+	for _, parsedTime := range parsedTimes {
+		sub.Time = parsedTime
+		if err := b.subscriptions.Add(sub); err != nil {
+			message := "Failed to subscribe channel to weather forecasts"
+			switch {
+			case errors.Is(err, usecase.ErrManagerClosed):
+				message = "The bot is shutting down, please try again shortly"
+			case isUnsupportedCapture(err):
+				message = unsupportedCaptureMessage(err)
+			case errors.Is(err, usecase.ErrDuplicateSubscription):
+				message = fmt.Sprintf(
+					"This channel is already subscribed to this forecast %s",
+					describeNewSchedule(sub, time.Now()),
+				)
+			default:
+				slog.Error(
+					"failed to add subscription for channel",
+					"channelID",
+					i.ChannelID,
+					"error",
+					err,
+				)
+			}
+			if len(schedules) > 0 {
+				message += fmt.Sprintf(
+					". Deliveries %s were subscribed before the failure",
+					strings.Join(schedules, ", "),
+				)
+			}
+			b.respondWithError(s, i, message)
+			return
+		}
+		schedules = append(schedules, describeNewSchedule(sub, time.Now()))
+	}
+
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Successfully subscribed this channel to receive weather forecasts at %s daily from %s", timeOption.StringValue(), url),
-			Flags:   discordgo.MessageFlagsEphemeral,
+			Content: fmt.Sprintf(
+				"Successfully subscribed this channel to receive weather forecasts %s from %s",
+				strings.Join(schedules, ", "),
+				url,
+			),
+			Flags: discordgo.MessageFlagsEphemeral,
 		},
 	}); err != nil {
 		slog.Error("failed to respond to interaction", "error", err)
 	}
 }
 
+// channelMentionPattern matches channel mentions such as <#123> as well as bare channel IDs.
+var channelMentionPattern = regexp.MustCompile(`<#(\d+)>|\b(\d{15,25})\b`)
+
+// parseExtraChannels extracts the channels named in value and checks that each belongs to the
+// interaction's guild and accepts forecasts from the bot. On failure it returns a message for the
+// user.
+func (b *WeatherBot) parseExtraChannels(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	value string,
+) ([]string, string) {
+	const required = discordgo.PermissionViewChannel |
+		discordgo.PermissionSendMessages |
+		discordgo.PermissionAttachFiles
+
+	var channelIDs []string
+	for _, match := range channelMentionPattern.FindAllStringSubmatch(value, -1) {
+		channelID := match[1]
+		if channelID == "" {
+			channelID = match[2]
+		}
+
+		if message := checkChannel(s, i, channelID, required); message != "" {
+			return nil, message
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+
+	if len(channelIDs) == 0 {
+		return nil, "Please mention the additional channels, e.g. #tokyo #osaka"
+	}
+	return channelIDs, ""
+}
+
+// checkChannel reports whether channelID belongs to the interaction's guild and grants the bot the
+// required permissions. On failure it returns a message for the user.
+func checkChannel(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	channelID string,
+	required int64,
+) string {
+	channel, err := s.State.Channel(channelID)
+	if err != nil {
+		channel, err = s.Channel(channelID)
+	}
+	if err != nil || channel.GuildID != i.GuildID {
+		return fmt.Sprintf("Channel <#%s> was not found in this server", channelID)
+	}
+
+	permissions, err := s.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil || permissions&required != required {
+		return fmt.Sprintf("I cannot post in <#%s>", channelID)
+	}
+
+	return ""
+}
+
 func (b *WeatherBot) handleUnsubscribeWeather(
 	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
 ) {
+	if !b.canManageSubscriptions(i) {
+		b.respondWithError(s, i, "You don't have permission to unsubscribe this channel")
+		return
+	}
+
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "index" {
+			b.unsubscribeOne(s, i, int(option.IntValue()))
+			return
+		}
+	}
+
 	count, err := b.subscriptions.Remove(i.ChannelID)
 	if err != nil {
 		slog.Error(
@@ -251,6 +1399,10 @@ func (b *WeatherBot) handleUnsubscribeWeather(
 		b.respondWithError(s, i, "Failed to unsubscribe channel from weather forecasts")
 		return
 	}
+	if count == 0 {
+		b.respondWithError(s, i, "This channel has no weather forecast subscriptions")
+		return
+	}
 
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -263,75 +1415,802 @@ func (b *WeatherBot) handleUnsubscribeWeather(
 	}
 }
 
-func (b *WeatherBot) handleCurrentWeather(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	}); err != nil {
-		slog.Error("failed to defer interaction", "error", err)
+// handleSetPaused pauses or, when paused is false, resumes the channel's subscriptions, or only
+// the one given by the index option.
+func (b *WeatherBot) handleSetPaused(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	paused bool,
+) {
+	verb := "pause"
+	if !paused {
+		verb = "resume"
+	}
+	if !b.canManageSubscriptions(i) {
+		b.respondWithError(
+			s,
+			i,
+			fmt.Sprintf("You don't have permission to %s this channel's forecasts", verb),
+		)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	imageData, err := b.weatherCapture.CaptureForecast(
-		ctx,
-		latestForecastURL,
-		defaultForecastSelector,
-	)
-	if err != nil {
-		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "Failed to capture weather forecast",
-		}); err != nil {
-			slog.Error("failed to send followup", "error", err)
+	index := 0
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "index" {
+			index = int(option.IntValue())
 		}
+	}
+
+	changed, err := b.subscriptions.SetPaused(context.Background(), i.ChannelID, index, paused)
+	switch {
+	case errors.Is(err, domain.ErrSubscriptionNotFound) && index != 0:
+		b.respondWithError(
+			s,
+			i,
+			fmt.Sprintf("This channel has no subscription %d. See /list-subscriptions", index),
+		)
+		return
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		b.respondWithError(s, i, "This channel has no weather forecast subscriptions")
+		return
+	case err != nil:
+		slog.Error(
+			"failed to change paused state of subscriptions",
+			"channelID",
+			i.ChannelID,
+			"paused",
+			paused,
+			"error",
+			err,
+		)
+		b.respondWithError(s, i, fmt.Sprintf("Failed to %s this channel's forecasts", verb))
 		return
 	}
 
-	if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content: "Here's the latest weather forecast! ☀️",
-		Files: []*discordgo.File{
-			{
-				Name:        "weather_forecast.png",
-				ContentType: "image/png",
-				Reader:      bytes.NewReader(imageData),
-			},
+	var content string
+	switch {
+	case changed == 0 && paused:
+		content = "Nothing to pause: the forecasts are already paused"
+	case changed == 0:
+		content = "Nothing to resume: the forecasts are not paused"
+	case paused:
+		content = fmt.Sprintf(
+			"Paused %d weather forecast subscription(s) in this channel. Use /resume to restart them",
+			changed,
+		)
+	default:
+		content = fmt.Sprintf("Resumed %d weather forecast subscription(s) in this channel", changed)
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	}); err != nil {
-		slog.Error("failed to send followup", "error", err)
+		slog.Error("failed to respond to interaction", "error", err)
 	}
 }
 
-func (b *WeatherBot) handleListSubscriptions(
+// unsubscribeOne removes the channel's subscription numbered index in /list-subscriptions.
+func (b *WeatherBot) unsubscribeOne(
 	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
+	index int,
 ) {
-	if i.GuildID == "" {
-		b.respondWithError(s, i, "Subscriptions can only be listed inside a server")
-		return
-	}
-
-	subs, err := b.subscriptions.ListByGuild(context.Background(), i.GuildID)
+	removed, err := b.subscriptions.RemoveOne(context.Background(), i.ChannelID, index)
 	if err != nil {
-		slog.Error("failed to list subscriptions for guild", "guildID", i.GuildID, "error", err)
-		b.respondWithError(s, i, "Failed to fetch subscriptions for this server")
+		slog.Error(
+			"failed to remove subscription for channel",
+			"channelID",
+			i.ChannelID,
+			"index",
+			index,
+			"error",
+			err,
+		)
+		b.respondWithError(s, i, "Failed to unsubscribe channel from weather forecasts")
+		return
+	}
+	if !removed {
+		b.respondWithError(
+			s,
+			i,
+			fmt.Sprintf("This channel has no subscription %d. See /list-subscriptions", index),
+		)
 		return
 	}
 
-	if len(subs) == 0 {
-		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "No weather subscriptions configured in this server.",
-				Flags:   discordgo.MessageFlagsEphemeral,
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Removed subscription %d from this channel", index),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("failed to respond to interaction", "error", err)
+	}
+}
+
+func (b *WeatherBot) handleCurrentWeather(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	format := domain.FormatPNG
+	var viewport domain.Viewport
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "format":
+			if parsed, err := domain.ParseFormat(option.StringValue()); err == nil {
+				format = parsed
+			}
+		case "viewport":
+			parsed, err := domain.ParseViewport(option.StringValue())
+			if err != nil {
+				b.respondWithError(s, i, validationMessage(err))
+				return
+			}
+			viewport = parsed
+		}
+	}
+
+	if b.captureLimiter != nil && !b.captureLimiter.Allow(i.GuildID, 1) {
+		b.respondWithError(s, i, "This server has reached its capture limit, please try again later")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("failed to defer interaction", "error", err)
+		return
+	}
+
+	settings := b.settings.Load()
+	ctx, cancel := context.WithTimeout(context.Background(), onDemandCaptureTimeout(settings))
+	defer cancel()
+
+	imageData, err := b.weatherCapture.CaptureForecast(ctx, domain.CaptureRequest{
+		URL:             settings.LatestForecastURL,
+		ElementSelector: settings.DefaultForecastSelector,
+		Viewport:        viewport,
+		Format:          format,
+	})
+	if err != nil {
+		b.followup(s, i, captureFailureMessage(err), nil)
+		return
+	}
+
+	b.followup(s, i, "Here's the latest weather forecast! ☀️", func() []*discordgo.File {
+		return []*discordgo.File{
+			{
+				Name:        format.FileName(),
+				ContentType: format.ContentType(),
+				Reader:      bytes.NewReader(imageData),
 			},
-		}); err != nil {
-			slog.Error("failed to respond to interaction", "error", err)
 		}
+	})
+}
+
+// defaultCaptureTimeout bounds on-demand captures when the operator configured no capture timeout.
+const defaultCaptureTimeout = 30 * time.Second
+
+// onDemandCaptureTimeout returns how long an on-demand capture may take under settings.
+func onDemandCaptureTimeout(settings usecase.Settings) time.Duration {
+	if settings.CaptureTimeout > 0 {
+		return settings.CaptureTimeout
+	}
+	return defaultCaptureTimeout
+}
+
+// interactionTokenLifetime is how long Discord accepts followups for an interaction.
+const interactionTokenLifetime = 15 * time.Minute
+
+// followupSafetyMargin is how close to token expiry a followup is no longer attempted.
+const followupSafetyMargin = time.Minute
+
+// followup answers a deferred interaction. When the interaction token has expired, or is about
+// to, the response is posted to the channel instead, mentioning the user, so slow captures are
+// not lost. files is called for each attempt since file readers can only be consumed once.
+func (b *WeatherBot) followup(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	content string,
+	files func() []*discordgo.File,
+) {
+	newFiles := func() []*discordgo.File {
+		if files == nil {
+			return nil
+		}
+		return files()
+	}
+
+	// Interaction IDs are snowflakes, so they carry the moment Discord issued the token.
+	issued, err := discordgo.SnowflakeTimestamp(i.ID)
+	expiring := err == nil &&
+		time.Since(issued) > interactionTokenLifetime-followupSafetyMargin
+	if !expiring {
+		_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: content,
+			Files:   newFiles(),
+		})
+		if err == nil {
+			return
+		}
+		if !isExpiredInteraction(err) {
+			slog.Error("failed to send followup", "error", err)
+			return
+		}
+	}
+
+	userID := interactionUserID(i)
+	if _, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Content:         fmt.Sprintf("<@%s> %s", userID, content),
+		Files:           newFiles(),
+		AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{userID}},
+	}); err != nil {
+		slog.Error("failed to post expired interaction response to channel", "error", err)
+	}
+}
+
+// isExpiredInteraction reports whether err means the interaction token is no longer valid.
+func isExpiredInteraction(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return false
+	}
+	if restErr.Message != nil {
+		switch restErr.Message.Code {
+		case discordgo.ErrCodeInvalidWebhookTokenProvided, discordgo.ErrCodeUnknownWebhook:
+			return true
+		}
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusUnauthorized
+}
+
+func (b *WeatherBot) handleForecastNow(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	sub, message := b.resolveSubscription(i)
+	if message != "" {
+		b.respondWithError(s, i, message)
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		slog.Error("failed to defer interaction", "error", err)
+		return
+	}
+
+	images, err := b.subscriptions.CaptureNow(context.Background(), sub)
+	if err != nil {
+		b.followup(s, i, captureFailureMessage(err), nil)
+		return
+	}
+
+	attachments := make([]attachment, 0, len(images))
+	for index, imageData := range images {
+		attachments = append(attachments, attachment{index: index, data: imageData})
+	}
+
+	b.followup(s, i, b.subscriptions.DeliveryMessage(sub), func() []*discordgo.File {
+		return forecastFiles(attachments, len(images), sub.Format)
+	})
+}
+
+func (b *WeatherBot) handleValidate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	sub, message := b.resolveSubscription(i)
+	if message != "" {
+		b.respondWithError(s, i, message)
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		slog.Error("failed to defer interaction", "error", err)
+		return
+	}
+
+	// Capture as PNG regardless of the delivery format so the image can be measured.
+	sub.Format = domain.FormatPNG
+	images, err := b.subscriptions.CaptureNow(context.Background(), sub)
+
+	var content string
+	switch {
+	case errors.Is(err, usecase.ErrCaptureLimitExceeded):
+		content = "This server has reached its capture limit, please try again later"
+	case err != nil:
+		content = fmt.Sprintf(
+			"❌ Subscription #%d (%s) could not be captured: %v",
+			sub.ID,
+			sub.DisplayLabel(),
+			err,
+		)
+	default:
+		var builder strings.Builder
+		fmt.Fprintf(
+			&builder,
+			"✅ Subscription #%d (%s) captured successfully:\n",
+			sub.ID,
+			sub.DisplayLabel(),
+		)
+		for index, imageData := range images {
+			fmt.Fprintf(&builder, "- image %d: %s\n", index+1, describeImage(imageData))
+		}
+		content = builder.String()
+	}
+
+	if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}); err != nil {
+		slog.Error("failed to send followup", "error", err)
+	}
+}
+
+// handlePreview captures a user-supplied URL and selector and returns the image privately, so a
+// target can be checked before it is subscribed to. Capture errors are shown to the user.
+func (b *WeatherBot) handlePreview(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	settings := b.settings.Load()
+	req := domain.CaptureRequest{
+		ElementSelector: settings.DefaultForecastSelector,
+		Format:          domain.FormatPNG,
+	}
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "url":
+			req.URL = option.StringValue()
+		case "selector":
+			if selector := strings.TrimSpace(option.StringValue()); selector != "" {
+				req.ElementSelector = domain.ParseSelector(selector)
+			}
+		}
+	}
+	if req.URL == "" {
+		b.respondWithError(s, i, "URL option is required")
+		return
+	}
+	parsedURL, err := domain.ParseSourceURL(req.URL)
+	if err != nil {
+		b.respondWithError(s, i, validationMessage(err))
+		return
+	}
+	req.URL = parsedURL
+
+	if b.captureLimiter != nil && !b.captureLimiter.Allow(i.GuildID, 1) {
+		b.respondWithError(s, i, "This server has reached its capture limit, please try again later")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		slog.Error("failed to defer interaction", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), onDemandCaptureTimeout(settings))
+	defer cancel()
+
+	params := &discordgo.WebhookParams{Flags: discordgo.MessageFlagsEphemeral}
+	imageData, err := b.weatherCapture.CaptureForecast(ctx, req)
+	if err != nil {
+		params.Content = fmt.Sprintf("❌ <%s> could not be captured: %v", req.URL, err)
+	} else {
+		params.Content = fmt.Sprintf(
+			"Preview of %s on <%s> (%s):",
+			describeTarget(req),
+			req.URL,
+			describeImage(imageData),
+		)
+		params.Files = []*discordgo.File{
+			{
+				Name:        req.Format.FileName(),
+				ContentType: req.Format.ContentType(),
+				Reader:      bytes.NewReader(imageData),
+			},
+		}
+	}
+
+	if _, err := s.FollowupMessageCreate(i.Interaction, true, params); err != nil {
+		slog.Error("failed to send followup", "error", err)
+	}
+}
+
+// describeImage reports the dimensions and size of a PNG capture.
+func describeImage(imageData []byte) string {
+	config, err := png.DecodeConfig(bytes.NewReader(imageData))
+	if err != nil {
+		return fmt.Sprintf("%d bytes, not a readable PNG", len(imageData))
+	}
+	return fmt.Sprintf("%d×%d px, %d KiB", config.Width, config.Height, len(imageData)/1024)
+}
+
+// resolveSubscription finds the subscription targeted by the interaction's optional id option,
+// defaulting to the channel's only subscription. On failure it returns a message for the user.
+func (b *WeatherBot) resolveSubscription(
+	i *discordgo.InteractionCreate,
+) (domain.Subscription, string) {
+	ctx := context.Background()
+
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name != "id" {
+			continue
+		}
+
+		sub, err := b.subscriptions.FindByID(ctx, uint(option.IntValue()))
+		if errors.Is(err, domain.ErrSubscriptionNotFound) ||
+			(err == nil && sub.GuildID != i.GuildID) {
+			return domain.Subscription{}, "No subscription with that ID exists in this server"
+		}
+		if err != nil {
+			slog.Error("failed to look up subscription", "error", err)
+			return domain.Subscription{}, "Failed to fetch the subscription"
+		}
+		return sub, ""
+	}
+
+	subs, err := b.subscriptions.ListByChannel(ctx, i.ChannelID)
+	if err != nil {
+		slog.Error("failed to list subscriptions for channel", "channelID", i.ChannelID, "error", err)
+		return domain.Subscription{}, "Failed to fetch subscriptions for this channel"
+	}
+
+	switch len(subs) {
+	case 0:
+		return domain.Subscription{}, "This channel has no subscriptions"
+	case 1:
+		return subs[0], ""
+	default:
+		return domain.Subscription{}, "This channel has several subscriptions. " +
+			"Please pass an id from /list-subscriptions"
+	}
+}
+
+func (b *WeatherBot) handleEditSubscription(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+) {
+	if !b.canManageSubscriptions(i) {
+		b.respondWithError(s, i, "You don't have permission to edit this channel's subscriptions")
+		return
+	}
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, option := range i.ApplicationCommandData().Options {
+		options[option.Name] = option
+	}
+	indexOption, ok := options["index"]
+	if !ok {
+		b.respondWithError(s, i, "Index option is required")
+		return
+	}
+	index := int(indexOption.IntValue())
+
+	ctx := context.Background()
+	subs, err := b.subscriptions.ListByChannel(ctx, i.ChannelID)
+	if err != nil {
+		slog.Error("failed to list subscriptions for channel", "channelID", i.ChannelID, "error", err)
+		b.respondWithError(s, i, "Failed to fetch subscriptions for this channel")
+		return
+	}
+	if index < 1 || index > len(subs) {
+		b.respondWithError(
+			s,
+			i,
+			fmt.Sprintf("This channel has no subscription %d. See /list-subscriptions", index),
+		)
+		return
+	}
+	sub := subs[index-1]
+
+	if option, ok := options["time"]; ok {
+		now := time.Now().In(sub.Location())
+		parsed, err := domain.ParseTimeOfDay(option.StringValue(), now)
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		sub.Time = parsed
+	}
+	if option, ok := options["message"]; ok {
+		sub.Message = option.StringValue()
+	}
+	if option, ok := options["label"]; ok {
+		sub.Label = strings.TrimSpace(option.StringValue())
+	}
+	if option, ok := options["url"]; ok && option.StringValue() != "" {
+		if sub.Mode == domain.ForecastModeLatest {
+			b.respondWithError(s, i, "The url option cannot be combined with the latest mode")
+			return
+		}
+		parsed, err := domain.ParseSourceURL(option.StringValue())
+		if err != nil {
+			b.respondWithError(s, i, validationMessage(err))
+			return
+		}
+		sub.URL = parsed
+	}
+	if option, ok := options["selector"]; ok && option.StringValue() != "" {
+		if !sub.Region.IsZero() {
+			b.respondWithError(s, i, "This subscription captures a region rather than a selector")
+			return
+		}
+		sub.ElementSelector = domain.ParseSelector(option.StringValue())
+	}
+
+	if err := sub.Validate(); err != nil {
+		b.respondWithError(s, i, validationMessage(err))
+		return
+	}
+
+	sub.UpdatedByUserID = interactionUserID(i)
+	if err := b.subscriptions.Update(ctx, i.ChannelID, index, sub); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSubscriptionNotFound):
+			b.respondWithError(s, i, "The subscription was removed in the meantime")
+		case errors.Is(err, usecase.ErrManagerClosed):
+			b.respondWithError(s, i, "The bot is shutting down, please try again shortly")
+		case isUnsupportedCapture(err):
+			b.respondWithError(s, i, unsupportedCaptureMessage(err))
+		case errors.Is(err, usecase.ErrDuplicateSubscription):
+			b.respondWithError(
+				s,
+				i,
+				"Another subscription in this channel already delivers this forecast at that time",
+			)
+		default:
+			slog.Error("failed to update subscription", "id", sub.ID, "error", err)
+			b.respondWithError(s, i, "Failed to update the subscription")
+		}
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf(
+				"Updated subscription %d: **%s** %s from %s",
+				index,
+				sub.DisplayLabel(),
+				describeSchedule(sub),
+				sub.URL,
+			),
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("failed to respond to interaction", "error", err)
+	}
+}
+
+// handleNextDelivery lists the upcoming deliveries of the channel's scheduled subscriptions,
+// soonest first.
+func (b *WeatherBot) handleNextDelivery(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	statuses, err := b.subscriptions.ListStatusByChannel(context.Background(), i.ChannelID)
+	if err != nil {
+		slog.Error("failed to read subscription status", "channelID", i.ChannelID, "error", err)
+		b.respondWithError(s, i, "Failed to fetch subscriptions for this channel")
+		return
+	}
+
+	scheduled := slices.DeleteFunc(statuses, func(status usecase.SubscriptionStatus) bool {
+		return status.NextRun.IsZero()
+	})
+	slices.SortFunc(scheduled, func(a, b usecase.SubscriptionStatus) int {
+		return a.NextRun.Compare(b.NextRun)
+	})
+
+	content := "No forecasts are scheduled for this channel."
+	if len(scheduled) > 0 {
+		var builder strings.Builder
+		builder.WriteString("Next deliveries in this channel:\n")
+		for _, status := range scheduled {
+			fmt.Fprintf(
+				&builder,
+				"- **%s**: <t:%d:F> (<t:%d:R>)%s\n",
+				status.DisplayLabel(),
+				status.NextRun.Unix(),
+				status.NextRun.Unix(),
+				pausedSuffix(status.Paused),
+			)
+		}
+		content = builder.String()
+	}
+
+	b.respondInPages(s, i, content)
+}
+
+func (b *WeatherBot) handleListSubscriptions(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+) {
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Name == "all_channels" && option.BoolValue() {
+			b.listGuildSubscriptions(s, i)
+			return
+		}
+	}
+
+	subs, err := b.subscriptions.ListByChannel(context.Background(), i.ChannelID)
+	if err != nil {
+		slog.Error("failed to list subscriptions for channel", "channelID", i.ChannelID, "error", err)
+		b.respondWithError(s, i, "Failed to fetch subscriptions for this channel")
+		return
+	}
+
+	statuses, err := b.subscriptions.ListStatusByChannel(context.Background(), i.ChannelID)
+	if err != nil {
+		slog.Error("failed to read subscription status", "channelID", i.ChannelID, "error", err)
+	}
+	scheduled := make(map[uint]usecase.SubscriptionStatus, len(statuses))
+	for _, status := range statuses {
+		scheduled[status.ID] = status
+	}
+
+	content := "This channel has no active subscriptions."
+	if len(subs) > 0 {
+		var builder strings.Builder
+		builder.WriteString("Subscriptions in this channel:\n")
+		for index, sub := range subs {
+			target := describeTarget(sub.CaptureRequest())
+			fmt.Fprintf(
+				&builder,
+				"%d. `#%d` **%s** %s — %s\n  <%s> · %s\n  Message: %s\n",
+				index+1,
+				sub.ID,
+				sub.DisplayLabel(),
+				describeSchedule(sub),
+				describeStatus(scheduled[sub.ID], err == nil),
+				sub.URL,
+				target,
+				sub.Message,
+			)
+			if authors := describeAuthors(sub); authors != "" {
+				fmt.Fprintf(&builder, "  %s\n", authors)
+			}
+		}
+		content = builder.String()
+	}
+
+	b.respondInPages(s, i, content)
+}
+
+func (b *WeatherBot) handleTransferSubscription(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+) {
+	if i.GuildID == "" {
+		b.respondWithError(s, i, "Subscriptions can only be transferred inside a server")
+		return
+	}
+	if !hasPermission(i, discordgo.PermissionManageGuild) {
+		b.respondWithError(s, i, "You need the Manage Server permission to transfer subscriptions")
+		return
+	}
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, option := range i.ApplicationCommandData().Options {
+		options[option.Name] = option
+	}
+	idOption, hasID := options["id"]
+	toOption, hasTo := options["to"]
+	if !hasID || !hasTo {
+		b.respondWithError(s, i, "Both id and to are required")
+		return
+	}
+
+	target := toOption.UserValue(nil)
+	member, err := s.GuildMember(i.GuildID, target.ID)
+	if err != nil {
+		b.respondWithError(s, i, "That user is not a member of this server")
+		return
+	}
+	if member.User != nil && member.User.Bot {
+		b.respondWithError(s, i, "Subscriptions cannot be owned by bots")
+		return
+	}
+
+	ctx := context.Background()
+	id := uint(idOption.IntValue())
+	sub, err := b.subscriptions.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrSubscriptionNotFound) || (err == nil && sub.GuildID != i.GuildID) {
+		b.respondWithError(s, i, "No subscription with that ID exists in this server")
+		return
+	}
+	if err != nil {
+		slog.Error("failed to look up subscription", "error", err)
+		b.respondWithError(s, i, "Failed to fetch the subscription")
+		return
+	}
+
+	previousOwner := sub.CreatedByUserID
+	if _, err := b.subscriptions.TransferOwnership(ctx, id, target.ID); err != nil {
+		slog.Error("failed to transfer subscription", "subscriptionID", id, "error", err)
+		b.respondWithError(s, i, "Failed to transfer the subscription")
+		return
+	}
+
+	from := "no recorded owner"
+	if previousOwner != "" {
+		from = fmt.Sprintf("<@%s>", previousOwner)
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf(
+				"Transferred subscription #%d in <#%s> from %s to <@%s>",
+				id,
+				sub.ChannelID,
+				from,
+				target.ID,
+			),
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	}); err != nil {
+		slog.Error("failed to respond to interaction", "error", err)
+	}
+}
+
+func (b *WeatherBot) handleGuildUsage(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.GuildID == "" {
+		b.respondWithError(s, i, "Usage can only be reported inside a server")
+		return
+	}
+	if !hasPermission(i, discordgo.PermissionManageGuild) {
+		b.respondWithError(s, i, "You need the Manage Server permission to view usage")
+		return
+	}
+
+	count, err := b.subscriptions.CountByGuild(context.Background(), i.GuildID)
+	if err != nil {
+		slog.Error("failed to count subscriptions for guild", "guildID", i.GuildID, "error", err)
+		b.respondWithError(s, i, "Failed to fetch usage for this server")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("This server has %d weather subscription(s) configured.", count),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("failed to respond to interaction", "error", err)
+	}
+}
+
+// listGuildSubscriptions answers /subscriptions-in-guild and /list-subscriptions all_channels with
+// every subscription in the interaction's server grouped by channel, so moderators can audit where
+// the bot posts. Both require Manage Server, since the listing reveals channels the member may not
+// be able to see.
+func (b *WeatherBot) listGuildSubscriptions(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+) {
+	if i.GuildID == "" {
+		b.respondWithError(s, i, "Subscriptions can only be listed inside a server")
+		return
+	}
+	if !hasPermission(i, discordgo.PermissionManageGuild) {
+		b.respondWithError(s, i, "You need the Manage Server permission to list subscriptions")
 		return
 	}
 
-	sort.Slice(subs, func(a, b int) bool {
+	subs, err := b.subscriptions.ListByGuild(context.Background(), i.GuildID)
+	if err != nil {
+		slog.Error("failed to list subscriptions for guild", "guildID", i.GuildID, "error", err)
+		b.respondWithError(s, i, "Failed to fetch subscriptions for this server")
+		return
+	}
+	if len(subs) == 0 {
+		b.respondInPages(s, i, "No weather subscriptions configured in this server.")
+		return
+	}
+
+	sort.SliceStable(subs, func(a, b int) bool {
 		if subs[a].ChannelID == subs[b].ChannelID {
 			return subs[a].Time.Before(subs[b].Time)
 		}
@@ -339,20 +2218,166 @@ func (b *WeatherBot) handleListSubscriptions(
 	})
 
 	var builder strings.Builder
-	builder.WriteString("Configured weather subscriptions:\n")
-	for _, sub := range subs {
-		builder.WriteString(fmt.Sprintf(
-			"- <#%s> at %s — %s\n",
-			sub.ChannelID,
-			sub.Time.Format("15:04"),
+	fmt.Fprintf(&builder, "This server has %d weather subscription(s):\n", len(subs))
+	for index, sub := range subs {
+		if index == 0 || subs[index-1].ChannelID != sub.ChannelID {
+			fmt.Fprintf(&builder, "\n<#%s>\n", sub.ChannelID)
+		}
+		fmt.Fprintf(
+			&builder,
+			"- `#%d` **%s** %s — <%s>\n",
+			sub.ID,
+			sub.DisplayLabel(),
+			describeSchedule(sub),
 			sub.URL,
-		))
+		)
+		if sub.CreatedByUserID != "" {
+			fmt.Fprintf(&builder, "  Owner: <@%s>\n", sub.CreatedByUserID)
+		}
+	}
+
+	b.respondInPages(s, i, builder.String())
+}
+
+// respondInPages answers the interaction privately with content, split into as many messages as
+// Discord's length limit requires. Mentions in content are not pinged.
+func (b *WeatherBot) respondInPages(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	content string,
+) {
+	pages := splitMessage(content, maxMessageContent)
+	messages := make([]*discordgo.WebhookParams, 0, len(pages))
+	for _, page := range pages {
+		messages = append(messages, &discordgo.WebhookParams{Content: page})
+	}
+
+	b.respondWithMessages(s, i, messages)
+}
+
+// respondWithMessages answers the interaction privately with the first message and sends the rest
+// as followups, in order. Mentions are not pinged.
+func (b *WeatherBot) respondWithMessages(
+	s *discordgo.Session,
+	i *discordgo.InteractionCreate,
+	messages []*discordgo.WebhookParams,
+) {
+	for _, message := range messages {
+		message.Flags = discordgo.MessageFlagsEphemeral
+		message.AllowedMentions = &discordgo.MessageAllowedMentions{}
 	}
 
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: builder.String(),
+			Content:         messages[0].Content,
+			Embeds:          messages[0].Embeds,
+			Flags:           messages[0].Flags,
+			AllowedMentions: messages[0].AllowedMentions,
+		},
+	}); err != nil {
+		slog.Error("failed to respond to interaction", "error", err)
+		return
+	}
+
+	for _, message := range messages[1:] {
+		if _, err := s.FollowupMessageCreate(i.Interaction, true, message); err != nil {
+			slog.Error("failed to send followup", "error", err)
+			return
+		}
+	}
+}
+
+// splitEmbed spreads embed's fields over as many embeds as Discord's per-embed limits require.
+// Continuation embeds repeat the title but not the description.
+func splitEmbed(embed *discordgo.MessageEmbed) []*discordgo.MessageEmbed {
+	page := &discordgo.MessageEmbed{Title: embed.Title, Description: embed.Description}
+	pages := []*discordgo.MessageEmbed{page}
+	size := utf8.RuneCountInString(page.Title + page.Description)
+	for _, field := range embed.Fields {
+		fieldSize := utf8.RuneCountInString(field.Name + field.Value)
+		if len(page.Fields) == maxEmbedFields || size+fieldSize > maxEmbedTotal {
+			page = &discordgo.MessageEmbed{Title: embed.Title + " (continued)"}
+			pages = append(pages, page)
+			size = utf8.RuneCountInString(page.Title)
+		}
+		page.Fields = append(page.Fields, field)
+		size += fieldSize
+	}
+
+	return pages
+}
+
+// splitMessage breaks text into pages of at most limit characters, cutting between lines where
+// possible. It always returns at least one page.
+func splitMessage(text string, limit int) []string {
+	var (
+		pages   []string
+		current strings.Builder
+		length  int
+	)
+	flush := func() {
+		if length > 0 {
+			pages = append(pages, current.String())
+			current.Reset()
+			length = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		runes := []rune(line)
+		if length+len(runes) > limit {
+			flush()
+		}
+		for len(runes) > limit {
+			pages = append(pages, string(runes[:limit]))
+			runes = runes[limit:]
+		}
+		current.WriteString(string(runes))
+		length += len(runes)
+	}
+	flush()
+
+	if len(pages) == 0 {
+		return []string{""}
+	}
+	return pages
+}
+
+func (b *WeatherBot) handleReloadConfig(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if b.reloadConfig == nil {
+		b.respondWithError(s, i, "Configuration reloading is not enabled")
+		return
+	}
+
+	isOwner, err := b.isBotOwner(s, interactionUserID(i))
+	if err != nil {
+		slog.Error("failed to resolve bot owners", "error", err)
+		b.respondWithError(s, i, "Failed to verify permissions")
+		return
+	}
+	if !isOwner {
+		b.respondWithError(s, i, "Only the bot owner can reload the configuration")
+		return
+	}
+
+	changed, err := b.reloadConfig()
+	if err != nil {
+		slog.Error("failed to reload configuration", "error", err)
+		b.respondWithError(s, i, fmt.Sprintf("Failed to reload configuration: %v", err))
+		return
+	}
+
+	content := "Configuration reloaded. No settings changed."
+	if len(changed) > 0 {
+		content = "Configuration reloaded. Changed: " + strings.Join(changed, ", ")
+	}
+	slog.Info("configuration reloaded", "changed", changed)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	}); err != nil {
@@ -360,6 +2385,338 @@ func (b *WeatherBot) handleListSubscriptions(
 	}
 }
 
+// isBotOwner reports whether userID owns the application, directly or through its team.
+// Owners are fetched once and cached for the lifetime of the bot.
+func (b *WeatherBot) isBotOwner(s *discordgo.Session, userID string) (bool, error) {
+	b.ownersMu.Lock()
+	defer b.ownersMu.Unlock()
+
+	if b.ownerIDs == nil {
+		application, err := s.Application("@me")
+		if err != nil {
+			return false, err
+		}
+
+		owners := make(map[string]struct{})
+		if application.Owner != nil {
+			owners[application.Owner.ID] = struct{}{}
+		}
+		if application.Team != nil {
+			for _, member := range application.Team.Members {
+				if member.User != nil {
+					owners[member.User.ID] = struct{}{}
+				}
+			}
+		}
+		b.ownerIDs = owners
+	}
+
+	_, ok := b.ownerIDs[userID]
+	return ok, nil
+}
+
+// interactionUserID returns the invoking user's ID for both guild and direct-message interactions.
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// hasPermission reports whether the invoking member holds permission in the interaction's channel.
+func hasPermission(i *discordgo.InteractionCreate, permission int64) bool {
+	if i.Member == nil {
+		return false
+	}
+
+	return i.Member.Permissions&permission == permission ||
+		i.Member.Permissions&discordgo.PermissionAdministrator != 0
+}
+
+// checkWebhook verifies that webhookURL is a Discord webhook posting to the interaction's channel.
+// On failure it returns a message for the user.
+func checkWebhook(s *discordgo.Session, i *discordgo.InteractionCreate, webhookURL string) string {
+	webhookID, token, err := parseWebhookURL(webhookURL)
+	if err != nil {
+		return "Invalid webhook URL. Copy it from the channel's Integrations → Webhooks settings"
+	}
+
+	webhook, err := s.WebhookWithToken(webhookID, token)
+	if err != nil {
+		slog.Warn("failed to look up webhook", "channelID", i.ChannelID, "error", err)
+		return "That webhook could not be found. It may have been deleted"
+	}
+	if webhook.ChannelID != i.ChannelID {
+		return "That webhook posts to a different channel. Please use a webhook of this channel"
+	}
+
+	return ""
+}
+
+// canManageSubscriptions reports whether the invoking user may change the channel's
+// subscriptions. Direct messages are always allowed because the user owns the conversation.
+func (b *WeatherBot) canManageSubscriptions(i *discordgo.InteractionCreate) bool {
+	if i.GuildID == "" || b.managePermission == 0 || hasPermission(i, b.managePermission) {
+		return true
+	}
+	if i.Member == nil {
+		return false
+	}
+
+	for _, roleID := range i.Member.Roles {
+		if slices.Contains(b.manageRoleIDs, roleID) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureFailureMessage converts a failed on-demand capture into a user-facing explanation.
+func captureFailureMessage(err error) string {
+	switch {
+	case errors.Is(err, usecase.ErrCaptureLimitExceeded):
+		return "This server has reached its capture limit, please try again later"
+	case errors.Is(err, domain.ErrUnsupportedFormat):
+		return "The capture service cannot produce this format, please choose another one"
+	case isUnsupportedCapture(err):
+		return unsupportedCaptureMessage(err)
+	default:
+		return "Failed to capture weather forecast"
+	}
+}
+
+// isUnsupportedCapture reports whether err is a *domain.UnsupportedCaptureError.
+func isUnsupportedCapture(err error) bool {
+	var unsupported *domain.UnsupportedCaptureError
+	return errors.As(err, &unsupported)
+}
+
+// unsupportedCaptureMessage names the options the capture service cannot honour. err must
+// satisfy isUnsupportedCapture.
+func unsupportedCaptureMessage(err error) string {
+	var unsupported *domain.UnsupportedCaptureError
+	errors.As(err, &unsupported)
+	return fmt.Sprintf(
+		"The capture service does not support %s yet. "+
+			"Please leave these options out or ask the bot operator to upgrade it",
+		unsupported.Options(),
+	)
+}
+
+// validationMessage converts a subscription validation failure into a user-facing explanation.
+func validationMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrIntervalTooShort):
+		return fmt.Sprintf(
+			"Interval must be at least %d hour(s)",
+			int(domain.MinimumInterval.Hours()),
+		)
+	case errors.Is(err, domain.ErrUnsupportedLanguage):
+		return "Unsupported language"
+	case errors.Is(err, domain.ErrInvalidCaptureTimeout):
+		return fmt.Sprintf(
+			"timeout_seconds must be between 1 and %d",
+			int(domain.MaxCaptureTimeout/time.Second),
+		)
+	case errors.Is(err, domain.ErrInvalidMaxStaleness):
+		return "max_staleness_hours must not be negative"
+	case errors.Is(err, domain.ErrUnsupportedAlignment):
+		return "Unsupported alignment. Please choose the given time or from now"
+	case errors.Is(err, domain.ErrInvalidStartDelay):
+		return "start_delay_minutes must not be negative"
+	case errors.Is(err, domain.ErrInvalidExtraChannels):
+		return fmt.Sprintf(
+			"Invalid additional channels. Mention up to %d different channels other than this one",
+			domain.MaxExtraChannels,
+		)
+	case errors.Is(err, domain.ErrInvalidWeekdays):
+		return "Invalid days. Use abbreviations such as mon,wed,fri, or weekdays, weekends or daily"
+	case errors.Is(err, domain.ErrInvalidKeywords):
+		return fmt.Sprintf(
+			"Invalid keywords. Use up to %d different comma-separated words with a selector, "+
+				"e.g. rain, storm",
+			domain.MaxKeywords,
+		)
+	case errors.Is(err, domain.ErrUnsupportedFormat):
+		return "Unsupported format. Please choose png, jpeg, webp or pdf"
+	case errors.Is(err, domain.ErrFormatNotFlattenable):
+		return "WebP captures cannot have a background. Please choose png, jpeg or pdf with background"
+	case errors.Is(err, domain.ErrInvalidURL):
+		return "Invalid URL. Please use a full http:// or https:// address such as https://tenki.jp/"
+	case errors.Is(err, domain.ErrInvalidLabel):
+		return fmt.Sprintf("label must be at most %d characters", domain.MaxLabelLength)
+	case errors.Is(err, domain.ErrInvalidMessage):
+		return fmt.Sprintf(
+			"message must be at most %d characters once {url} and the other placeholders expand",
+			domain.MaxMessageLength,
+		)
+	case errors.Is(err, domain.ErrInvalidQuality):
+		return "quality must be between 1 and 100"
+	case errors.Is(err, domain.ErrInvalidColor):
+		return "Invalid background color. Please use a hex color such as #ffffff or #1e2a38"
+	case errors.Is(err, domain.ErrInvalidMatchIndex):
+		return "index must not be negative"
+	case errors.Is(err, domain.ErrInvalidRegion):
+		return fmt.Sprintf(
+			"Invalid region. Use x,y,width,height in pixels, e.g. 0,120,800,600 "+
+				"(width and height up to %d)",
+			domain.MaxRegionSize,
+		)
+	case errors.Is(err, domain.ErrInvalidViewport):
+		return fmt.Sprintf(
+			"Invalid viewport. Use widthxheight, optionally with @scale, e.g. 1280x800@2 "+
+				"(sizes %d-%d, scale %g-%g)",
+			domain.MinViewportSize,
+			domain.MaxViewportSize,
+			domain.MinViewportScale,
+			domain.MaxViewportScale,
+		)
+	case errors.Is(err, domain.ErrUnknownTimezone):
+		return "Unknown timezone. Please use an IANA name such as Asia/Tokyo or Europe/London"
+	case errors.Is(err, domain.ErrUnsupportedForecastMode):
+		return "Unsupported mode. Please choose fixed or latest"
+	case errors.Is(err, domain.ErrInvalidTimeOfDay):
+		return "Invalid time format. Please use HH:MM, optionally with a UTC offset " +
+			"(e.g., 08:00 or 08:00+09:00)"
+	case errors.Is(err, domain.ErrInvalidForecastDays):
+		return fmt.Sprintf(
+			"Invalid forecast days. Use up to %d comma-separated offsets between 0 and %d, e.g. 0,1,2",
+			domain.MaxForecastDays,
+			domain.MaxForecastDayOffset,
+		)
+	default:
+		return "Invalid subscription settings"
+	}
+}
+
+func formatChoices() []*discordgo.ApplicationCommandOptionChoice {
+	return []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "PNG image", Value: string(domain.FormatPNG)},
+		{Name: "JPEG image (smaller)", Value: string(domain.FormatJPEG)},
+		{Name: "WebP image (smaller)", Value: string(domain.FormatWebP)},
+		{Name: "PDF document", Value: string(domain.FormatPDF)},
+	}
+}
+
+func languageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(domain.SupportedLanguages))
+	for _, language := range domain.SupportedLanguages {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  language.Name,
+			Value: language.Tag,
+		})
+	}
+
+	return choices
+}
+
+// describeNewSchedule describes the schedule of a subscription that is being added. Creation-aligned
+// subscriptions have no Time yet, so their first delivery is described relative to now.
+func describeNewSchedule(sub domain.Subscription, now time.Time) string {
+	if sub.Alignment != domain.AlignToCreation {
+		return describeSchedule(sub)
+	}
+
+	cadence := "daily"
+	if sub.EveryN > 0 {
+		cadence = fmt.Sprintf("every %d hour(s)", int(sub.EveryN/time.Hour))
+	}
+	return fmt.Sprintf("%s starting <t:%d:R>", cadence, now.Add(sub.StartDelay).Unix())
+}
+
+// describeSchedule renders the delivery cadence of sub for user-facing messages.
+func describeSchedule(sub domain.Subscription) string {
+	at := sub.Time.Format("15:04")
+	if sub.Timezone != "" {
+		at += " " + sub.Timezone
+	}
+
+	if sub.EveryN > 0 {
+		return fmt.Sprintf("every %d hour(s) from %s%s", int(sub.EveryN/time.Hour), at, describeDays(sub))
+	}
+	if sub.Days != 0 {
+		return fmt.Sprintf("at %s%s", at, describeDays(sub))
+	}
+
+	return fmt.Sprintf("at %s daily", at)
+}
+
+// describeAuthors names who created and last edited sub, or returns "" when neither is known.
+func describeAuthors(sub domain.Subscription) string {
+	var parts []string
+	if sub.CreatedByUserID != "" {
+		parts = append(parts, fmt.Sprintf("Created by <@%s>", sub.CreatedByUserID))
+	}
+	if sub.UpdatedByUserID != "" {
+		parts = append(parts, fmt.Sprintf("edited by <@%s>", sub.UpdatedByUserID))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	authors := strings.Join(parts, " · ")
+	return strings.ToUpper(authors[:1]) + authors[1:]
+}
+
+// describeTarget renders the part of the page req captures.
+func describeTarget(req domain.CaptureRequest) string {
+	switch {
+	case !req.Region.IsZero():
+		return fmt.Sprintf("region `%s`", req.Region)
+	case req.FullPage():
+		return "the whole page"
+	default:
+		return fmt.Sprintf("selector `%s`", req.ElementSelector)
+	}
+}
+
+// describeStatus renders the timer state of a listed subscription. A zero status means the
+// subscription is stored but not scheduled, which happens after repeated failures; known is false
+// when the status could not be read at all.
+func describeStatus(status usecase.SubscriptionStatus, known bool) string {
+	switch {
+	case !known:
+		return "status unknown"
+	case status.ID == 0 && status.NextRun.IsZero():
+		return "⏸️ stopped after repeated failures until the bot restarts"
+	case status.Paused:
+		return "🔇 paused until /resume"
+	case status.NextRun.IsZero():
+		return "scheduling"
+	}
+
+	description := fmt.Sprintf("next <t:%d:R>", status.NextRun.Unix())
+	if status.ConsecutiveFailures > 0 {
+		description += fmt.Sprintf(", ⚠️ %d failed in a row", status.ConsecutiveFailures)
+	}
+	return description
+}
+
+// pausedSuffix marks a paused subscription's delivery as skipped.
+func pausedSuffix(paused bool) string {
+	if paused {
+		return " — 🔇 paused, skipped"
+	}
+	return ""
+}
+
+// describeDays renders the days sub is delivered on, or nothing when it is delivered every day.
+func describeDays(sub domain.Subscription) string {
+	switch sub.Days {
+	case 0:
+		return ""
+	case domain.WeekdaysWorkweek:
+		return " on weekdays"
+	case domain.WeekdaysWeekend:
+		return " on weekends"
+	default:
+		return " on " + strings.ReplaceAll(sub.Days.String(), ",", ", ")
+	}
+}
+
 func (b *WeatherBot) respondWithError(
 	s *discordgo.Session,
 	i *discordgo.InteractionCreate,
@@ -0,0 +1,581 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/gorilla/websocket"
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+	"github.com/sglre6355/weather-lady/internal/usecase/usecasetest"
+)
+
+// discordRequest is a REST call the bot made to Discord.
+type discordRequest struct {
+	Method string
+	Path   string
+	// Payload is the JSON body, or the payload_json part of a multipart upload.
+	Payload map[string]any
+}
+
+// content returns the message content of the request, including an interaction response's.
+func (r discordRequest) content() string {
+	if content, ok := r.Payload["content"].(string); ok {
+		return content
+	}
+	if data, ok := r.Payload["data"].(map[string]any); ok {
+		content, _ := data["content"].(string)
+		return content
+	}
+	return ""
+}
+
+// ephemeral reports whether the message is only shown to the member who invoked the command.
+func (r discordRequest) ephemeral() bool {
+	payload := r.Payload
+	if data, ok := payload["data"].(map[string]any); ok {
+		payload = data
+	}
+	flags, _ := payload["flags"].(float64)
+	return discordgo.MessageFlags(flags)&discordgo.MessageFlagsEphemeral != 0
+}
+
+// fakeDiscord is an http.RoundTripper standing in for the Discord REST API. It records every
+// request and answers with respond, or 200 and an empty object when respond returns zero.
+type fakeDiscord struct {
+	respond func(method, path string) (int, string)
+
+	mu       sync.Mutex
+	requests []discordRequest
+}
+
+func (f *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := discordRequest{
+		Method: req.Method,
+		Path:   strings.TrimPrefix(req.URL.Path, "/api/v"+discordgo.APIVersion),
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		recorded.Payload = decodePayload(req.Header.Get("Content-Type"), body)
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, recorded)
+	f.mu.Unlock()
+
+	status, body := 0, ""
+	if f.respond != nil {
+		status, body = f.respond(recorded.Method, recorded.Path)
+	}
+	if status == 0 {
+		status, body = http.StatusOK, "{}"
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// Requests returns the requests made so far, in order.
+func (f *fakeDiscord) Requests() []discordRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]discordRequest(nil), f.requests...)
+}
+
+func decodePayload(contentType string, body []byte) map[string]any {
+	var payload map[string]any
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if mediaType != "multipart/form-data" {
+		_ = json.Unmarshal(body, &payload)
+		return payload
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return payload
+		}
+		if part.FormName() == "payload_json" {
+			_ = json.NewDecoder(part).Decode(&payload)
+		}
+	}
+}
+
+// newTestSession returns a session whose REST calls are answered by a fakeDiscord.
+func newTestSession(t *testing.T) (*discordgo.Session, *fakeDiscord) {
+	t.Helper()
+
+	session, err := discordgo.New("Bot test-token")
+	if err != nil {
+		t.Fatalf("discordgo.New: %v", err)
+	}
+	discord := &fakeDiscord{}
+	session.Client = &http.Client{Transport: discord}
+	session.ShouldRetryOnRateLimit = false
+	return session, discord
+}
+
+// newTestBot returns a bot backed by an unstarted subscription manager and a fakeDiscord.
+func newTestBot(
+	t *testing.T,
+	manager *usecase.SubscriptionManager,
+	opts ...WeatherBotOption,
+) (*WeatherBot, *fakeDiscord) {
+	t.Helper()
+
+	capture := &usecasetest.FakeCapture{Image: make([]byte, 128)}
+	if manager == nil {
+		manager = usecase.NewSubscriptionManager(capture, usecasetest.NewFakeSender(16))
+	}
+	t.Cleanup(func() { manager.Shutdown() })
+
+	session, discord := newTestSession(t)
+	bot, err := NewWeatherBot(session, manager, capture, opts...)
+	if err != nil {
+		t.Fatalf("NewWeatherBot: %v", err)
+	}
+	return bot, discord
+}
+
+// commandInteraction returns an invocation of the named slash command in a guild channel by a
+// member holding permissions.
+func commandInteraction(
+	name string,
+	permissions int64,
+	options ...*discordgo.ApplicationCommandInteractionDataOption,
+) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:        "1",
+		AppID:     "app",
+		Token:     "interaction-token",
+		Type:      discordgo.InteractionApplicationCommand,
+		GuildID:   "guild",
+		ChannelID: "channel",
+		Member: &discordgo.Member{
+			User:        &discordgo.User{ID: "member"},
+			Permissions: permissions,
+		},
+		Data: discordgo.ApplicationCommandInteractionData{Name: name, Options: options},
+	}}
+}
+
+// boolOption returns a boolean command option.
+func boolOption(name string, value bool) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{
+		Name:  name,
+		Type:  discordgo.ApplicationCommandOptionBoolean,
+		Value: value,
+	}
+}
+
+// interactionResponses returns the bot's initial responses to interactions, in order.
+func interactionResponses(discord *fakeDiscord) []discordRequest {
+	var responses []discordRequest
+	for _, request := range discord.Requests() {
+		if strings.HasPrefix(request.Path, "/interactions/") {
+			responses = append(responses, request)
+		}
+	}
+	return responses
+}
+
+func TestGuildSubscriptionListing(t *testing.T) {
+	const manageGuild = discordgo.PermissionManageGuild
+	allChannels := []*discordgo.ApplicationCommandInteractionDataOption{
+		boolOption("all_channels", true),
+	}
+
+	tests := []struct {
+		name        string
+		command     string
+		options     []*discordgo.ApplicationCommandInteractionDataOption
+		permissions int64
+		subscribed  bool
+		want        string
+	}{
+		{
+			name:        "all channels without Manage Server",
+			command:     "list-subscriptions",
+			options:     allChannels,
+			permissions: discordgo.PermissionManageChannels,
+			subscribed:  true,
+			want:        "You need the Manage Server permission to list subscriptions",
+		},
+		{
+			name:        "all channels with Manage Server",
+			command:     "list-subscriptions",
+			options:     allChannels,
+			permissions: manageGuild,
+			subscribed:  true,
+			want:        "This server has 1 weather subscription(s):",
+		},
+		{
+			name:        "subscriptions-in-guild without Manage Server",
+			command:     "subscriptions-in-guild",
+			permissions: 0,
+			subscribed:  true,
+			want:        "You need the Manage Server permission to list subscriptions",
+		},
+		{
+			name:        "subscriptions-in-guild matches all channels",
+			command:     "subscriptions-in-guild",
+			permissions: manageGuild,
+			subscribed:  true,
+			want:        "This server has 1 weather subscription(s):",
+		},
+		{
+			name:        "empty server",
+			command:     "subscriptions-in-guild",
+			permissions: manageGuild,
+			want:        "No weather subscriptions configured in this server.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, discord := newTestBot(t, nil)
+			bot.ready.Store(true)
+			if tt.subscribed {
+				err := bot.subscriptions.Add(domain.Subscription{
+					ChannelID: "channel",
+					GuildID:   "guild",
+					URL:       "https://example.com/forecast",
+					Message:   "Forecast",
+				})
+				if err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+
+			interaction := commandInteraction(tt.command, tt.permissions, tt.options...)
+			bot.onInteractionCreate(bot.session, interaction)
+
+			responses := interactionResponses(discord)
+			if len(responses) != 1 || !strings.HasPrefix(responses[0].content(), tt.want) {
+				t.Fatalf("responses = %v, want one starting with %q", responses, tt.want)
+			}
+			if !responses[0].ephemeral() {
+				t.Errorf("response %q is visible to the whole channel", responses[0].content())
+			}
+		})
+	}
+}
+
+// messagesSent returns the channel messages the bot posted, in order.
+func messagesSent(discord *fakeDiscord) []discordRequest {
+	var messages []discordRequest
+	for _, request := range discord.Requests() {
+		if request.Method == http.MethodPost &&
+			strings.HasPrefix(request.Path, "/channels/") &&
+			strings.HasSuffix(request.Path, "/messages") {
+			messages = append(messages, request)
+		}
+	}
+	return messages
+}
+
+func TestGuildCreateWelcomesOnlyNewGuilds(t *testing.T) {
+	guild := func(id, systemChannelID string) *discordgo.GuildCreate {
+		return &discordgo.GuildCreate{Guild: &discordgo.Guild{
+			ID:              id,
+			SystemChannelID: systemChannelID,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		welcome bool
+		events  []*discordgo.GuildCreate
+		// want lists the system channels welcomed, in order.
+		want []string
+	}{
+		{
+			name:    "startup hydration of guilds from Ready",
+			welcome: true,
+			events:  []*discordgo.GuildCreate{guild("ready-guild", "ready-system")},
+		},
+		{
+			name:    "newly joined guild",
+			welcome: true,
+			events:  []*discordgo.GuildCreate{guild("new-guild", "new-system")},
+			want:    []string{"new-system"},
+		},
+		{
+			name:    "newly joined guild seen again after a reconnect",
+			welcome: true,
+			events: []*discordgo.GuildCreate{
+				guild("new-guild", "new-system"),
+				guild("new-guild", "new-system"),
+			},
+			want: []string{"new-system"},
+		},
+		{
+			name:    "guild recovering from an outage",
+			welcome: true,
+			events: []*discordgo.GuildCreate{{Guild: &discordgo.Guild{
+				ID:              "unavailable-guild",
+				SystemChannelID: "unavailable-system",
+				Unavailable:     true,
+			}}},
+		},
+		{
+			name:    "newly joined guild without a system channel",
+			welcome: true,
+			events:  []*discordgo.GuildCreate{guild("new-guild", "")},
+		},
+		{
+			name:   "welcome message disabled",
+			events: []*discordgo.GuildCreate{guild("new-guild", "new-system")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, discord := newTestBot(t, nil, WithWelcomeMessage(tt.welcome))
+			bot.session.State.User = &discordgo.User{ID: "bot", Username: "weather-lady"}
+			bot.onReady(bot.session, &discordgo.Ready{
+				Guilds: []*discordgo.Guild{{ID: "ready-guild", Unavailable: true}},
+			})
+
+			for _, event := range tt.events {
+				bot.onGuildCreate(bot.session, event)
+			}
+
+			var welcomed []string
+			for _, message := range messagesSent(discord) {
+				channelID := strings.TrimPrefix(message.Path, "/channels/")
+				welcomed = append(welcomed, strings.TrimSuffix(channelID, "/messages"))
+				if message.content() != welcomeMessage {
+					t.Errorf("welcome message = %q, want %q", message.content(), welcomeMessage)
+				}
+			}
+			if !slices.Equal(welcomed, tt.want) {
+				t.Errorf("welcomed system channels %v, want %v", welcomed, tt.want)
+			}
+		})
+	}
+}
+
+// fakeOpener is a sessionOpener failing with each of errs in turn, then succeeding.
+type fakeOpener struct {
+	errs  []error
+	calls int
+}
+
+func (o *fakeOpener) Open() error {
+	o.calls++
+	if o.calls <= len(o.errs) {
+		return o.errs[o.calls-1]
+	}
+	return nil
+}
+
+func TestStartRetriesOpen(t *testing.T) {
+	authenticationFailed := &websocket.CloseError{Code: 4004}
+	unauthorized := &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusUnauthorized},
+	}
+
+	tests := []struct {
+		name      string
+		attempts  int
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "first attempt succeeds", attempts: 3, wantCalls: 1},
+		{
+			name:      "fails then succeeds",
+			attempts:  3,
+			errs:      []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after the last attempt",
+			attempts:  2,
+			errs:      []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, io.ErrUnexpectedEOF},
+			wantCalls: 2,
+			wantErr:   io.ErrUnexpectedEOF,
+		},
+		{
+			name:      "authentication failure is not retried",
+			attempts:  3,
+			errs:      []error{authenticationFailed},
+			wantCalls: 1,
+			wantErr:   authenticationFailed,
+		},
+		{
+			name:      "unauthorized REST call is not retried",
+			attempts:  3,
+			errs:      []error{unauthorized},
+			wantCalls: 1,
+			wantErr:   unauthorized,
+		},
+		{
+			name:      "already open is not retried",
+			attempts:  3,
+			errs:      []error{discordgo.ErrWSAlreadyOpen},
+			wantCalls: 1,
+			wantErr:   discordgo.ErrWSAlreadyOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, _ := newTestBot(t, nil, WithOpenRetry(tt.attempts, time.Millisecond))
+			opener := &fakeOpener{errs: tt.errs}
+			bot.opener = opener
+
+			err := bot.Start()
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Start: %v", err)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("Start = %v, want %v", err, tt.wantErr)
+			}
+			if opener.calls != tt.wantCalls {
+				t.Errorf("opened %d times, want %d", opener.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// snowflakeAt returns a Discord snowflake ID issued at t.
+func snowflakeAt(t time.Time) string {
+	const discordEpochMillis = 1420070400000
+	return strconv.FormatInt((t.UnixMilli()-discordEpochMillis)<<22, 10)
+}
+
+func TestFollowupFallsBackToChannel(t *testing.T) {
+	const webhookPath = "/webhooks/app/interaction-token"
+
+	tests := []struct {
+		name string
+		// issued is how long before the followup Discord issued the interaction.
+		issued      time.Duration
+		status      int
+		body        string
+		wantWebhook bool
+		wantChannel bool
+	}{
+		{
+			name:        "token still valid",
+			issued:      time.Minute,
+			wantWebhook: true,
+		},
+		{
+			name:        "token rejected as unauthorized",
+			issued:      time.Minute,
+			status:      http.StatusUnauthorized,
+			body:        `{"message": "401: Unauthorized", "code": 0}`,
+			wantWebhook: true,
+			wantChannel: true,
+		},
+		{
+			name:        "webhook of the token unknown",
+			issued:      time.Minute,
+			status:      http.StatusNotFound,
+			body:        `{"message": "Unknown Webhook", "code": 10015}`,
+			wantWebhook: true,
+			wantChannel: true,
+		},
+		{
+			name:        "followup failing for another reason",
+			issued:      time.Minute,
+			status:      http.StatusBadRequest,
+			body:        `{"message": "Invalid Form Body", "code": 50035}`,
+			wantWebhook: true,
+		},
+		{
+			name:        "token about to expire",
+			issued:      interactionTokenLifetime - followupSafetyMargin/2,
+			wantChannel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, discord := newTestBot(t, nil)
+			discord.respond = func(method, path string) (int, string) {
+				if path == webhookPath {
+					return tt.status, tt.body
+				}
+				return 0, ""
+			}
+			interaction := commandInteraction("forecast-now", 0)
+			interaction.ID = snowflakeAt(time.Now().Add(-tt.issued))
+
+			bot.followup(bot.session, interaction, "Forecast", func() []*discordgo.File {
+				return []*discordgo.File{{
+					Name:        "forecast.png",
+					ContentType: "image/png",
+					Reader:      strings.NewReader("image"),
+				}}
+			})
+
+			var webhook, channel []discordRequest
+			for _, request := range discord.Requests() {
+				switch {
+				case request.Path == webhookPath:
+					webhook = append(webhook, request)
+				case request.Path == "/channels/channel/messages":
+					channel = append(channel, request)
+				}
+			}
+			if got := len(webhook) > 0; got != tt.wantWebhook {
+				t.Errorf("followup attempted = %t, want %t", got, tt.wantWebhook)
+			}
+			if !tt.wantChannel {
+				if len(channel) != 0 {
+					t.Errorf("posted %d channel messages, want none", len(channel))
+				}
+				return
+			}
+			if len(channel) != 1 {
+				t.Fatalf("posted %d channel messages, want 1", len(channel))
+			}
+			if got, want := channel[0].content(), "<@member> Forecast"; got != want {
+				t.Errorf("channel message = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestInteractionBeforeReady(t *testing.T) {
+	bot, discord := newTestBot(t, nil)
+
+	bot.onInteractionCreate(bot.session, commandInteraction("subscriptions-in-guild", 0))
+
+	const want = "The bot is starting up, please try again in a moment"
+	responses := interactionResponses(discord)
+	if len(responses) != 1 || !strings.HasPrefix(responses[0].content(), want) {
+		t.Fatalf("responses = %v, want one starting with %q", responses, want)
+	}
+	if !responses[0].ephemeral() {
+		t.Errorf("response %q is visible to the whole channel", responses[0].content())
+	}
+	if requests := discord.Requests(); len(requests) != 1 {
+		t.Errorf("requests = %v, want only the starting-up reply", requests)
+	}
+}
@@ -0,0 +1,131 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+)
+
+// errInvalidWebhookURL is returned for URLs that are not Discord webhook URLs.
+var errInvalidWebhookURL = errors.New("not a Discord webhook URL")
+
+// WebhookForecastSender posts deliveries that name a webhook through that webhook, so they
+// appear under the webhook's (or the configured) name and avatar. Other deliveries are passed
+// to the fallback sender.
+type WebhookForecastSender struct {
+	session   *discordgo.Session
+	fallback  usecase.ForecastSender
+	username  string
+	avatarURL string
+	retries   sendRetries
+}
+
+// WebhookForecastSenderOption customises a WebhookForecastSender.
+type WebhookForecastSenderOption func(*WebhookForecastSender)
+
+// WithWebhookIdentity overrides the name and avatar of webhook posts. Empty values keep the
+// webhook's own settings.
+func WithWebhookIdentity(username, avatarURL string) WebhookForecastSenderOption {
+	return func(s *WebhookForecastSender) {
+		s.username = username
+		s.avatarURL = avatarURL
+	}
+}
+
+// WithWebhookSendRetries retries webhook posts that fail with a transient Discord error, as
+// WithSendRetries does for bot messages.
+func WithWebhookSendRetries(attempts int, baseDelay time.Duration) WebhookForecastSenderOption {
+	return func(s *WebhookForecastSender) {
+		s.retries = s.retries.with(attempts, baseDelay)
+	}
+}
+
+// NewWebhookForecastSender routes webhook deliveries itself and everything else to fallback.
+func NewWebhookForecastSender(
+	session *discordgo.Session,
+	fallback usecase.ForecastSender,
+	opts ...WebhookForecastSenderOption,
+) *WebhookForecastSender {
+	s := &WebhookForecastSender{session: session, fallback: fallback, retries: defaultSendRetries}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SendForecast executes the delivery's webhook with its captures and message. Transient failures
+// are retried and images that would exceed the upload limit are dropped and reported through a
+// *usecase.PartialDeliveryError, as with bot messages. Reply anchors are not supported by webhooks
+// and are ignored.
+func (s *WebhookForecastSender) SendForecast(ctx context.Context, delivery domain.Delivery) error {
+	if delivery.WebhookURL == "" {
+		return s.fallback.SendForecast(ctx, delivery)
+	}
+	if s.session == nil {
+		return fmt.Errorf("discord session is not initialised")
+	}
+
+	webhookID, token, err := parseWebhookURL(delivery.WebhookURL)
+	if err != nil {
+		return err
+	}
+
+	return sendWithinUploadLimit(
+		withFallbackNote(delivery),
+		"execute forecast webhook",
+		func(delivery domain.Delivery, attachments []attachment) error {
+			return s.retries.do(ctx, func() error {
+				return s.execute(ctx, webhookID, token, delivery, attachments)
+			})
+		},
+	)
+}
+
+func (s *WebhookForecastSender) execute(
+	ctx context.Context,
+	webhookID string,
+	token string,
+	delivery domain.Delivery,
+	attachments []attachment,
+) error {
+	if _, err := s.session.WebhookExecute(webhookID, token, false, &discordgo.WebhookParams{
+		Content:   delivery.Message,
+		Username:  s.username,
+		AvatarURL: s.avatarURL,
+		Files:     forecastFiles(attachments, len(delivery.Images), delivery.Format),
+	}, discordgo.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to execute forecast webhook: %w", err)
+	}
+
+	return nil
+}
+
+// parseWebhookURL extracts the ID and token from a URL such as
+// https://discord.com/api/webhooks/<id>/<token>.
+func parseWebhookURL(raw string) (string, string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Scheme != "https" {
+		return "", "", errInvalidWebhookURL
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(parsed.Hostname(), "canary."), "ptb.")
+	if host != "discord.com" && host != "discordapp.com" {
+		return "", "", errInvalidWebhookURL
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "api" || parts[1] != "webhooks" ||
+		parts[2] == "" || parts[3] == "" {
+		return "", "", errInvalidWebhookURL
+	}
+
+	return parts[2], parts[3], nil
+}
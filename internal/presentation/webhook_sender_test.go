@@ -0,0 +1,129 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+	"github.com/sglre6355/weather-lady/internal/usecase/usecasetest"
+)
+
+func TestWebhookForecastSenderRetries(t *testing.T) {
+	const webhookPath = "/webhooks/123/token"
+	rateLimited := `{"message": "You are being rate limited.", "retry_after": 0.001, "global": false}`
+
+	tests := []struct {
+		name string
+		// statuses answer the webhook posts in order; later posts succeed.
+		statuses    []int
+		body        string
+		images      [][]byte
+		wantPosts   int
+		wantDropped []int
+		wantErr     bool
+	}{
+		{
+			name:      "server error",
+			statuses:  []int{http.StatusServiceUnavailable},
+			images:    [][]byte{make([]byte, 128)},
+			wantPosts: 2,
+		},
+		{
+			name:      "rate limit",
+			statuses:  []int{http.StatusTooManyRequests},
+			body:      rateLimited,
+			images:    [][]byte{make([]byte, 128)},
+			wantPosts: 2,
+		},
+		{
+			name: "persistent server error",
+			statuses: []int{
+				http.StatusServiceUnavailable,
+				http.StatusServiceUnavailable,
+				http.StatusServiceUnavailable,
+			},
+			images:    [][]byte{make([]byte, 128)},
+			wantPosts: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "client error",
+			statuses:  []int{http.StatusBadRequest},
+			images:    [][]byte{make([]byte, 128)},
+			wantPosts: 1,
+			wantErr:   true,
+		},
+		{
+			name:        "payload too large",
+			statuses:    []int{http.StatusRequestEntityTooLarge},
+			images:      [][]byte{make([]byte, 128), make([]byte, 256)},
+			wantPosts:   2,
+			wantDropped: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, discord := newTestSession(t)
+			var mu sync.Mutex
+			statuses := tt.statuses
+			discord.respond = func(_, path string) (int, string) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if path != webhookPath || len(statuses) == 0 {
+					return 0, ""
+				}
+				status := statuses[0]
+				statuses = statuses[1:]
+				body := tt.body
+				if body == "" {
+					body = `{"message": "` + http.StatusText(status) + `", "code": 0}`
+				}
+				return status, body
+			}
+			sender := NewWebhookForecastSender(
+				session,
+				usecasetest.NewFakeSender(1),
+				WithWebhookSendRetries(3, time.Millisecond),
+			)
+
+			err := sender.SendForecast(context.Background(), domain.Delivery{
+				ChannelID:  "channel",
+				WebhookURL: "https://discord.com/api/webhooks/123/token",
+				Message:    "Forecast",
+				Images:     tt.images,
+			})
+
+			var partial *usecase.PartialDeliveryError
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Error("SendForecast succeeded, want an error")
+				}
+			case tt.wantDropped != nil:
+				if !errors.As(err, &partial) || !slices.Equal(partial.Dropped, tt.wantDropped) {
+					t.Errorf("SendForecast = %v, want images %v dropped", err, tt.wantDropped)
+				}
+			case err != nil:
+				t.Errorf("SendForecast: %v", err)
+			}
+
+			posts := 0
+			for _, request := range discord.Requests() {
+				if request.Path == webhookPath {
+					posts++
+				}
+			}
+			if posts != tt.wantPosts {
+				t.Errorf("webhook posted %d times, want %d", posts, tt.wantPosts)
+			}
+		})
+	}
+}
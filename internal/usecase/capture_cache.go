@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// CaptureCache shares captures of identical requests made within a short time, so channels
+// subscribed to the same source at the same minute cost one capture. Concurrent identical
+// requests wait for a single capture; failures are not cached. Cached images are shared between
+// callers and must not be modified.
+type CaptureCache struct {
+	capture ForecastCapture
+	ttl     time.Duration
+	nowFn   func() time.Time
+
+	mu      sync.Mutex
+	entries map[domain.CaptureRequest]*cachedCapture
+}
+
+type cachedCapture struct {
+	// done is closed once imageData, err and abandoned are set.
+	done      chan struct{}
+	imageData []byte
+	err       error
+	expiresAt time.Time
+	// abandoned reports that the capture failed because the context of the caller that started
+	// it ended, which says nothing about the callers waiting for it.
+	abandoned bool
+}
+
+// NewCaptureCache keeps successful captures from capture for ttl.
+func NewCaptureCache(capture ForecastCapture, ttl time.Duration) *CaptureCache {
+	return &CaptureCache{
+		capture: capture,
+		ttl:     ttl,
+		nowFn:   time.Now,
+		entries: make(map[domain.CaptureRequest]*cachedCapture),
+	}
+}
+
+// CaptureForecast returns a cached capture of req when one is fresh or in progress, and captures
+// it otherwise. A capture in progress runs under the context of the caller that started it, so
+// when that context ends first, the callers waiting for it capture again under their own.
+func (c *CaptureCache) CaptureForecast(
+	ctx context.Context,
+	req domain.CaptureRequest,
+) ([]byte, error) {
+	for {
+		entry, ok := c.lookup(req)
+		if !ok {
+			return c.captureInto(ctx, req, entry)
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.abandoned && ctx.Err() == nil {
+			continue
+		}
+		return entry.imageData, entry.err
+	}
+}
+
+// lookup returns the fresh or in-progress capture of req and true, or registers and returns a new
+// entry the caller must fill with captureInto.
+func (c *CaptureCache) lookup(req domain.CaptureRequest) (*cachedCapture, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFn()
+	for key, entry := range c.entries {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+
+	if entry, ok := c.entries[req]; ok {
+		return entry, true
+	}
+	entry := &cachedCapture{done: make(chan struct{})}
+	c.entries[req] = entry
+	return entry, false
+}
+
+// captureInto captures req into entry and releases the callers waiting for it.
+func (c *CaptureCache) captureInto(
+	ctx context.Context,
+	req domain.CaptureRequest,
+	entry *cachedCapture,
+) ([]byte, error) {
+	entry.imageData, entry.err = c.capture.CaptureForecast(ctx, req)
+	entry.abandoned = entry.err != nil && ctx.Err() != nil
+
+	c.mu.Lock()
+	if entry.err != nil {
+		delete(c.entries, req)
+	} else {
+		entry.expiresAt = c.nowFn().Add(c.ttl)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+
+	return entry.imageData, entry.err
+}
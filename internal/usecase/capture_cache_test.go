@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+)
+
+// cancellableCapture blocks its first capture until the caller's context ends, failing it as the
+// capture service does, and answers later captures with testImage.
+type cancellableCapture struct {
+	started chan struct{}
+	calls   atomic.Int32
+}
+
+func (c *cancellableCapture) CaptureForecast(
+	ctx context.Context,
+	_ domain.CaptureRequest,
+) ([]byte, error) {
+	if c.calls.Add(1) > 1 {
+		return testImage, nil
+	}
+	close(c.started)
+	<-ctx.Done()
+	return nil, errors.New("rpc error: code = Canceled desc = context canceled")
+}
+
+func TestCaptureCacheOutlivesFirstCaller(t *testing.T) {
+	capture := &cancellableCapture{started: make(chan struct{})}
+	cache := usecase.NewCaptureCache(capture, time.Minute)
+	req := domain.CaptureRequest{URL: "https://example.com/forecast", ElementSelector: "#forecast"}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.CaptureForecast(firstCtx, req)
+		firstErr <- err
+	}()
+	receive(t, capture.started)
+
+	type result struct {
+		image []byte
+		err   error
+	}
+	waiting := make(chan result, 1)
+	go func() {
+		image, err := cache.CaptureForecast(context.Background(), req)
+		waiting <- result{image, err}
+	}()
+	// Give the second caller time to join the capture in progress before it is abandoned.
+	time.Sleep(10 * time.Millisecond)
+	cancelFirst()
+
+	if err := receive(t, firstErr); err == nil {
+		t.Error("the cancelled caller's capture succeeded")
+	}
+	got := receive(t, waiting)
+	if got.err != nil || !bytes.Equal(got.image, testImage) {
+		t.Fatalf("waiting caller got (%d bytes, %v), want the forecast", len(got.image), got.err)
+	}
+	if calls := capture.calls.Load(); calls != 2 {
+		t.Errorf("captured %d times, want the abandoned capture and one retry", calls)
+	}
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCaptureLimitExceeded is returned when a guild has used up its capture allowance.
+var ErrCaptureLimitExceeded = errors.New("guild capture limit reached")
+
+// CaptureLimiter decides whether a guild may trigger more captures.
+type CaptureLimiter interface {
+	// Allow consumes n captures from guildID's allowance and reports whether they were available.
+	Allow(guildID string, n int) bool
+}
+
+// captureBucket is the token bucket of a single guild.
+type captureBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// GuildCaptureLimiter is a CaptureLimiter granting every guild a token bucket of perHour captures
+// that refills continuously over an hour.
+type GuildCaptureLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*captureBucket
+	capacity  float64
+	lastSweep time.Time
+	nowFn     func() time.Time
+}
+
+// GuildCaptureLimiterOption customises a GuildCaptureLimiter.
+type GuildCaptureLimiterOption func(*GuildCaptureLimiter)
+
+// WithCaptureLimiterClock overrides the clock used to refill buckets (useful for testing).
+func WithCaptureLimiterClock(nowFn func() time.Time) GuildCaptureLimiterOption {
+	return func(l *GuildCaptureLimiter) {
+		if nowFn != nil {
+			l.nowFn = nowFn
+		}
+	}
+}
+
+// NewGuildCaptureLimiter returns a limiter allowing each guild perHour captures per hour.
+func NewGuildCaptureLimiter(perHour int, opts ...GuildCaptureLimiterOption) *GuildCaptureLimiter {
+	limiter := &GuildCaptureLimiter{
+		buckets:  make(map[string]*captureBucket),
+		capacity: float64(perHour),
+		nowFn:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(limiter)
+	}
+
+	return limiter
+}
+
+// Allow consumes n captures from guildID's bucket if it holds enough tokens. Captures outside a
+// guild (direct messages) are not limited.
+func (l *GuildCaptureLimiter) Allow(guildID string, n int) bool {
+	if guildID == "" {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.nowFn()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[guildID]
+	if !ok {
+		bucket = &captureBucket{tokens: l.capacity, updated: now}
+		l.buckets[guildID] = bucket
+	}
+	bucket.tokens = l.refilled(bucket, now)
+	bucket.updated = now
+
+	if bucket.tokens < float64(n) {
+		return false
+	}
+	bucket.tokens -= float64(n)
+	return true
+}
+
+func (l *GuildCaptureLimiter) refilled(bucket *captureBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.updated)
+	if elapsed <= 0 {
+		return bucket.tokens
+	}
+	return min(l.capacity, bucket.tokens+l.capacity*elapsed.Hours())
+}
+
+// sweep drops buckets that have refilled completely, at most once per hour. A full bucket is
+// indistinguishable from a new one, so inactive guilds stop occupying memory.
+func (l *GuildCaptureLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Hour {
+		return
+	}
+	l.lastSweep = now
+
+	for guildID, bucket := range l.buckets {
+		if l.refilled(bucket, now) >= l.capacity {
+			delete(l.buckets, guildID)
+		}
+	}
+}
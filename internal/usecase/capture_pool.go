@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// CapturePool bounds how many captures run concurrently through the wrapped ForecastCapture.
+// Separate pools let on-demand commands and scheduled deliveries share a backend without one
+// starving the other.
+type CapturePool struct {
+	capture ForecastCapture
+	slots   chan struct{}
+}
+
+// NewCapturePool allows at most size concurrent captures through capture. A size of zero or less
+// returns a pool without a limit.
+func NewCapturePool(capture ForecastCapture, size int) *CapturePool {
+	pool := &CapturePool{capture: capture}
+	if size > 0 {
+		pool.slots = make(chan struct{}, size)
+	}
+	return pool
+}
+
+// CaptureForecast waits for a free slot, or until ctx is done, and then captures req.
+func (p *CapturePool) CaptureForecast(
+	ctx context.Context,
+	req domain.CaptureRequest,
+) ([]byte, error) {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+			defer func() { <-p.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return p.capture.CaptureForecast(ctx, req)
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"maps"
+	"slices"
+)
+
+// NextRun exposes nextRun to the external tests.
+var NextRun = (*SubscriptionManager).nextRun
+
+// FollowingRun exposes followingRun to the external tests.
+var FollowingRun = (*SubscriptionManager).followingRun
+
+// ScheduledChannels returns the channels m holds scheduled subscriptions for, sorted.
+func (m *SubscriptionManager) ScheduledChannels() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(m.subscriptions))
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+)
+
+// SecondaryDeliveryError is returned by MultiSender when the primary sender delivered the
+// forecast but a secondary one, such as the archive, failed. The delivery counts as sent; the
+// error is reported for visibility.
+type SecondaryDeliveryError struct {
+	Err error
+}
+
+func (e *SecondaryDeliveryError) Error() string {
+	return fmt.Sprintf("forecast delivered, but a secondary sender failed: %v", e.Err)
+}
+
+func (e *SecondaryDeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// MultiSender delivers every forecast through several senders, e.g. posting it to Discord and
+// archiving it, so one subscription can do both.
+type MultiSender struct {
+	senders []ForecastSender
+}
+
+// NewMultiSender fans deliveries out to senders, in order. The first is the primary sender, whose
+// outcome decides whether a delivery was sent; the others are secondary.
+func NewMultiSender(senders ...ForecastSender) *MultiSender {
+	return &MultiSender{senders: senders}
+}
+
+// SendForecast passes delivery to every sender, even when an earlier one fails. The errors are
+// returned joined when the primary sender failed, and wrapped in a SecondaryDeliveryError when
+// only secondary senders did.
+func (m *MultiSender) SendForecast(ctx context.Context, delivery domain.Delivery) error {
+	var primaryErr error
+	var errs []error
+	for i, sender := range m.senders {
+		err := sender.SendForecast(ctx, delivery)
+		if err == nil {
+			continue
+		}
+		if i == 0 {
+			primaryErr = err
+		}
+		errs = append(errs, err)
+	}
+
+	switch {
+	case primaryErr != nil:
+		return errors.Join(errs...)
+	case len(errs) > 0:
+		return &SecondaryDeliveryError{Err: errors.Join(errs...)}
+	default:
+		return nil
+	}
+}
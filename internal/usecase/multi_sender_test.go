@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+	"github.com/sglre6355/weather-lady/internal/usecase/usecasetest"
+)
+
+func TestMultiSender(t *testing.T) {
+	errPost := errors.New("discord unavailable")
+	errArchive := errors.New("archive disk is full")
+
+	tests := []struct {
+		name          string
+		primaryErr    error
+		archiveErr    error
+		wantSecondary bool
+		wantErrs      []error
+	}{
+		{name: "both delivered"},
+		{
+			name:          "archive failed",
+			archiveErr:    errArchive,
+			wantSecondary: true,
+			wantErrs:      []error{errArchive},
+		},
+		{
+			name:       "post failed",
+			primaryErr: errPost,
+			wantErrs:   []error{errPost},
+		},
+		{
+			name:       "both failed",
+			primaryErr: errPost,
+			archiveErr: errArchive,
+			wantErrs:   []error{errPost, errArchive},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &usecasetest.FakeSender{Err: tt.primaryErr}
+			archive := &usecasetest.FakeSender{Err: tt.archiveErr}
+			sender := usecase.NewMultiSender(primary, archive)
+
+			err := sender.SendForecast(context.Background(), domain.Delivery{ChannelID: "channel"})
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("SendForecast = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("SendForecast = %v, want it to wrap %v", err, want)
+				}
+			}
+			var secondary *usecase.SecondaryDeliveryError
+			if got := errors.As(err, &secondary); got != tt.wantSecondary {
+				t.Errorf("SendForecast = %v, secondary failure = %t, want %t", err, got, tt.wantSecondary)
+			}
+		})
+	}
+}
+
+func TestArchiveFailureCountsAsDelivered(t *testing.T) {
+	scheduled := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	clock := usecasetest.NewFakeClock(scheduled.Add(-time.Hour))
+	primary := usecasetest.NewFakeSender(1)
+	archive := &usecasetest.FakeSender{Err: errors.New("archive disk is full")}
+	delivered := make(chan domain.Subscription, 1)
+	stages := make(chan usecase.SubscriptionErrorStage, 4)
+
+	manager := usecase.NewSubscriptionManager(
+		&usecasetest.FakeCapture{Image: testImage},
+		usecase.NewMultiSender(primary, archive),
+		usecase.WithSubscriptionClock(clock.Now),
+		usecase.WithClockResyncInterval(time.Millisecond),
+		usecase.WithMaxConsecutiveFailures(1),
+		usecase.WithSubscriptionDeliveryHandler(func(sub domain.Subscription) {
+			delivered <- sub
+		}),
+		usecase.WithSubscriptionErrorHandler(
+			func(_ domain.Subscription, stage usecase.SubscriptionErrorStage, _ error) {
+				stages <- stage
+			},
+		),
+	)
+	t.Cleanup(func() { manager.Shutdown() })
+	if err := manager.Add(testSubscription("channel", 8)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitForNextRun(t, manager, "channel")
+
+	clock.Set(scheduled)
+	receive(t, primary.Delivered)
+	receive(t, delivered)
+	if stage := receive(t, stages); stage != usecase.SubscriptionErrorStageDispatch {
+		t.Errorf("archive failure reported at stage %q, want %q", stage,
+			usecase.SubscriptionErrorStageDispatch)
+	}
+
+	// A failed delivery would have stopped the subscription after one failure.
+	waitForNextRunAt(t, manager, "channel", scheduled.AddDate(0, 0, 1))
+	if got := manager.ScheduledChannels(); len(got) != 1 {
+		t.Errorf("scheduled channels = %v, want the subscription still scheduled", got)
+	}
+}
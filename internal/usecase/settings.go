@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+)
+
+// Settings are operator-tunable values that may be replaced while the bot is running.
+type Settings struct {
+	DefaultForecastURL      string
+	DefaultForecastSelector string
+	LatestForecastURL       string
+	CaptureTimeout          time.Duration
+	DispatchTimeout         time.Duration
+	LogLevel                string
+}
+
+// SettingsHolder guards the current Settings so components read live values instead of copies
+// taken at construction.
+type SettingsHolder struct {
+	mu       sync.RWMutex
+	settings Settings
+}
+
+// NewSettingsHolder returns a holder initialised with settings.
+func NewSettingsHolder(settings Settings) *SettingsHolder {
+	return &SettingsHolder{settings: settings}
+}
+
+// Load returns the current settings.
+func (h *SettingsHolder) Load() Settings {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.settings
+}
+
+// Replace swaps in next and returns the names of the settings whose values changed.
+func (h *SettingsHolder) Replace(next Settings) []string {
+	h.mu.Lock()
+	previous := h.settings
+	h.settings = next
+	h.mu.Unlock()
+
+	var changed []string
+	if previous.DefaultForecastURL != next.DefaultForecastURL {
+		changed = append(changed, "default forecast URL")
+	}
+	if previous.DefaultForecastSelector != next.DefaultForecastSelector {
+		changed = append(changed, "default forecast selector")
+	}
+	if previous.LatestForecastURL != next.LatestForecastURL {
+		changed = append(changed, "latest forecast URL")
+	}
+	if previous.CaptureTimeout != next.CaptureTimeout {
+		changed = append(changed, "capture timeout")
+	}
+	if previous.DispatchTimeout != next.DispatchTimeout {
+		changed = append(changed, "dispatch timeout")
+	}
+	if previous.LogLevel != next.LogLevel {
+		changed = append(changed, "log level")
+	}
+
+	return changed
+}
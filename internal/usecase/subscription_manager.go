@@ -1,9 +1,14 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sglre6355/weather-lady/internal/domain"
@@ -11,20 +16,112 @@ import (
 
 // ForecastCapture exposes the ability to render a forecast snapshot for a given source.
 type ForecastCapture interface {
-	CaptureForecast(ctx context.Context, url, elementSelector string) ([]byte, error)
+	CaptureForecast(ctx context.Context, req domain.CaptureRequest) ([]byte, error)
 }
 
 // ForecastSender delivers a rendered forecast to the desired destination.
 type ForecastSender interface {
-	SendForecast(ctx context.Context, channelID string, imageData []byte, message string) error
+	SendForecast(ctx context.Context, delivery domain.Delivery) error
 }
 
 // SubscriptionStore persists subscriptions and retrieves them for restoration.
 type SubscriptionStore interface {
-	Create(ctx context.Context, subscription domain.Subscription) error
+	Create(ctx context.Context, subscription domain.Subscription) (domain.Subscription, error)
 	List(ctx context.Context) ([]domain.Subscription, error)
+	ListPaged(ctx context.Context, offset, limit int) ([]domain.Subscription, error)
+	FindByID(ctx context.Context, id uint) (domain.Subscription, error)
+	ListByChannel(ctx context.Context, channelID string) ([]domain.Subscription, error)
 	ListByGuild(ctx context.Context, guildID string) ([]domain.Subscription, error)
+	CountByGuild(ctx context.Context, guildID string) (int, error)
+	UpdateOwner(ctx context.Context, id uint, userID string) error
+	UpdatePaused(ctx context.Context, id uint, paused bool) error
+	UpdateNextRun(ctx context.Context, id uint, nextRunAt time.Time) error
+	ClaimNextRun(ctx context.Context, id uint, missed, nextRunAt time.Time) (bool, error)
+	ListDueBetween(ctx context.Context, start, end time.Time) ([]domain.Subscription, error)
+	ClearFirstDeliveryConfirmation(ctx context.Context, id uint) error
 	DeleteByChannel(ctx context.Context, channelID string) (int, error)
+	DeleteByID(ctx context.Context, id uint) (bool, error)
+	UpdateByID(ctx context.Context, subscription domain.Subscription) error
+}
+
+// ForecastTextExtractor reads the text of a forecast element, for keyword filters.
+type ForecastTextExtractor interface {
+	ExtractForecastText(ctx context.Context, req domain.CaptureRequest) (string, error)
+}
+
+// CaptureFeatureSource reports which optional features the capture service supports.
+type CaptureFeatureSource interface {
+	CaptureFeatures(ctx context.Context) (domain.CaptureFeatures, error)
+}
+
+// SubscriberNotifier sends direct messages to subscription owners.
+type SubscriberNotifier interface {
+	NotifyUser(ctx context.Context, userID, message string) error
+}
+
+// ChannelNotifier posts plain text notices to a channel.
+type ChannelNotifier interface {
+	NotifyChannel(ctx context.Context, channelID, message string) error
+}
+
+// DeliveryLocker records which instance makes each scheduled delivery, so several bot instances
+// sharing one store post a subscription only once per run.
+type DeliveryLocker interface {
+	// ClaimDelivery reports whether owner claimed the delivery of the subscription stored under id
+	// scheduled for scheduledAt, and false when another instance claimed it first.
+	ClaimDelivery(ctx context.Context, id uint, scheduledAt time.Time, owner string) (bool, error)
+}
+
+// errorNoticeInterval is the minimum time between failure notices for one subscription, so a
+// broken hourly subscription does not flood its error channel.
+const errorNoticeInterval = 6 * time.Hour
+
+// SubscriptionMetrics records subscription lifecycle events for monitoring.
+type SubscriptionMetrics interface {
+	SubscriptionCreated()
+	SubscriptionsRemoved(count int)
+	SetActiveSubscriptions(count int)
+	CaptureRateLimited()
+	CaptureAttempted()
+	DispatchAttempted()
+}
+
+type noopSubscriptionMetrics struct{}
+
+func (noopSubscriptionMetrics) SubscriptionCreated()       {}
+func (noopSubscriptionMetrics) SubscriptionsRemoved(int)   {}
+func (noopSubscriptionMetrics) SetActiveSubscriptions(int) {}
+func (noopSubscriptionMetrics) CaptureRateLimited()        {}
+func (noopSubscriptionMetrics) CaptureAttempted()          {}
+func (noopSubscriptionMetrics) DispatchAttempted()         {}
+
+// loadPageSize is how many stored subscriptions LoadExisting reads per query.
+const loadPageSize = 500
+
+// ErrManagerClosed is returned when subscriptions are added after Shutdown.
+var ErrManagerClosed = errors.New("subscription manager is shut down")
+
+// ErrDuplicateSubscription is returned by Add and Update when the channel already has another
+// subscription delivering the same URL and selector at the same time.
+var ErrDuplicateSubscription = errors.New("channel already has an identical subscription")
+
+// ErrEmptyCapture is returned when the capture service answers with no usable image, e.g. because
+// the selector matched an element with nothing rendered.
+var ErrEmptyCapture = errors.New("capture returned no image data")
+
+// minCaptureSize is the fewest bytes a real capture can have; a PNG of a single pixel is larger.
+const minCaptureSize = 64
+
+// PartialDeliveryError is returned by a ForecastSender that posted a multi-image delivery without
+// some of its images. The delivery counts as sent; the error is reported for visibility.
+type PartialDeliveryError struct {
+	// Dropped lists the positions of the omitted images within the delivery.
+	Dropped []int
+	Total   int
+}
+
+func (e *PartialDeliveryError) Error() string {
+	return fmt.Sprintf("%d of %d images were omitted from the delivery", len(e.Dropped), e.Total)
 }
 
 // SubscriptionErrorStage indicates which step of the delivery pipeline failed.
@@ -35,30 +132,138 @@ const (
 	SubscriptionErrorStageCapture SubscriptionErrorStage = "capture"
 	// SubscriptionErrorStageDispatch marks failures while dispatching the snapshot to the consumer.
 	SubscriptionErrorStageDispatch SubscriptionErrorStage = "dispatch"
+	// SubscriptionErrorStageConfirmation marks failures while confirming a first delivery to the
+	// subscription's owner. The delivery itself succeeded.
+	SubscriptionErrorStageConfirmation SubscriptionErrorStage = "confirmation"
+	// SubscriptionErrorStageSchedule marks failures while persisting the next delivery instant.
+	// Deliveries continue; only the stored schedule is stale.
+	SubscriptionErrorStageSchedule SubscriptionErrorStage = "schedule"
+	// SubscriptionErrorStageAbort marks a subscription whose schedule was stopped after too many
+	// consecutive failed deliveries. It stays in the store and is scheduled again on restart.
+	SubscriptionErrorStageAbort SubscriptionErrorStage = "abort"
+	// SubscriptionErrorStageReconcile marks a disagreement between the stored subscriptions and
+	// the scheduled ones, e.g. after a partial restore.
+	SubscriptionErrorStageReconcile SubscriptionErrorStage = "reconcile"
 )
 
 // SubscriptionErrorHandler is invoked when a scheduled run cannot complete successfully.
 type SubscriptionErrorHandler func(domain.Subscription, SubscriptionErrorStage, error)
 
+// SubscriptionDeliveryHandler is invoked after a scheduled run has been dispatched successfully.
+type SubscriptionDeliveryHandler func(domain.Subscription)
+
+// DeliveryLagHandler is invoked when a scheduled run fires, with how late it fired relative to
+// the intended instant. Large values indicate goroutine starvation or clock problems.
+type DeliveryLagHandler func(domain.Subscription, time.Duration)
+
+// CaptureStatsHandler is invoked after every capture made for a subscription, scheduled or on
+// demand, with how long it took, the size of the image in bytes and the error, if it failed.
+// Slow or growing captures show a source that is about to time out.
+type CaptureStatsHandler func(sub domain.Subscription, duration time.Duration, size int, err error)
+
 type subscriptionEntry struct {
 	subscription domain.Subscription
 	stopChan     chan struct{}
+	// firstRun, when set, replaces the wall-clock computation of the first delivery.
+	firstRun time.Time
+	// catchUp marks firstRun as a missed delivery claimed in the store. It fires immediately and
+	// is not recorded as the next run, so no other instance claims it again.
+	catchUp bool
+
+	// lastImages and lastCapturedAt hold the most recent successful capture for stale fallback.
+	// They are only accessed by the entry's schedule goroutine.
+	lastImages     [][]byte
+	lastCapturedAt time.Time
+	// confirmed is set once the first delivery has been confirmed to the owner, so the
+	// confirmation is sent at most once even before the store reflects it. It carries over to the
+	// entry that replaces this one when the subscription is edited.
+	confirmed atomic.Bool
+	// lastErrorNotice is when a failure was last posted to the subscription's error channel.
+	lastErrorNotice time.Time
+	// failingSince is when the current streak of failed deliveries began.
+	failingSince time.Time
+	// failures counts consecutive failed deliveries and nextRun holds the Unix nanoseconds of the
+	// next delivery. Both are written by the schedule goroutine and read by ListStatusByChannel.
+	failures atomic.Int32
+	nextRun  atomic.Int64
+	// paused mirrors the subscription's Paused flag, which SetPaused changes while the schedule
+	// keeps running.
+	paused atomic.Bool
+	// started, when set, is called once the schedule goroutine has computed and recorded its
+	// first delivery.
+	started func()
+}
+
+// SubscriptionStatus is a scheduled subscription together with the state of its timer.
+type SubscriptionStatus struct {
+	domain.Subscription
+	// NextRun is when the subscription is next delivered.
+	NextRun time.Time
+	// ConsecutiveFailures counts the failed deliveries since the last successful one.
+	ConsecutiveFailures int
 }
 
 // SubscriptionManager coordinates scheduled forecast deliveries for channels.
 type SubscriptionManager struct {
 	mu            sync.RWMutex
 	subscriptions map[string][]*subscriptionEntry
+	active        int
+	closed        bool
+	// stopped maps the IDs of stored subscriptions that were stopped after repeated failures to
+	// their channel. They stay in the store without a schedule until the next restart.
+	stopped map[uint]string
+	// started is set by Start. Until then schedules are queued in pending rather than run.
+	started bool
+	pending []*subscriptionEntry
+	// adding holds the subscriptions Add is persisting and has not scheduled yet, so a concurrent
+	// Add of the same delivery is rejected as a duplicate before either is stored.
+	adding []*domain.Subscription
 
-	capture ForecastCapture
-	sender  ForecastSender
-	store   SubscriptionStore
+	// runCtx bounds every scheduled delivery; Shutdown cancels it once deliveries still running
+	// after drainTimeout are given up on. running tracks the schedule goroutines. parent carries
+	// runCtx's values, and cancelling it shuts the manager down.
+	parent       context.Context
+	runCtx       context.Context
+	cancelRuns   context.CancelFunc
+	running      sync.WaitGroup
+	drainTimeout time.Duration
+	// loadConcurrency bounds how many restored schedules may start at once; zero is unlimited.
+	loadConcurrency int
+	// catchUpWindow is how late a delivery missed while the bot was down may still be made up
+	// for on restore; zero skips missed deliveries.
+	catchUpWindow time.Duration
+
+	capture         ForecastCapture
+	onDemandCapture ForecastCapture
+	textExtractor   ForecastTextExtractor
+	features        CaptureFeatureSource
+	sender          ForecastSender
+	notifier        SubscriberNotifier
+	errorNotifier   ChannelNotifier
+	store           SubscriptionStore
+	// locker, when set, is claimed before each scheduled delivery under instanceID.
+	locker     DeliveryLocker
+	instanceID string
 
 	nowFn           func() time.Time
 	interval        time.Duration
+	resyncInterval  time.Duration
 	captureTimeout  time.Duration
 	dispatchTimeout time.Duration
+	staleFallback   time.Duration
+	maxFailures     int
+	alertThreshold  int
+	jitter          time.Duration
+	captureAttempts int
+	retryDelay      time.Duration
+	alignment       domain.Alignment
+	settings        *SettingsHolder
+	limiter         CaptureLimiter
 	onError         SubscriptionErrorHandler
+	onDeliveryLag   DeliveryLagHandler
+	onCaptured      CaptureStatsHandler
+	onDelivered     SubscriptionDeliveryHandler
+	metrics         SubscriptionMetrics
 }
 
 // SubscriptionManagerOption configures behavioural aspects of the scheduler.
@@ -82,6 +287,26 @@ func WithSubscriptionInterval(interval time.Duration) SubscriptionManagerOption
 	}
 }
 
+// WithClockResyncInterval bounds how long the scheduler sleeps before re-reading the wall clock.
+func WithClockResyncInterval(interval time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if interval > 0 {
+			m.resyncInterval = interval
+		}
+	}
+}
+
+// WithScheduleJitter delays each subscription's deliveries by a stable offset in [0, jitter), so
+// subscriptions sharing a popular time do not all capture at the same instant. jitter should be
+// well below the shortest delivery interval.
+func WithScheduleJitter(jitter time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if jitter > 0 {
+			m.jitter = jitter
+		}
+	}
+}
+
 // WithCaptureTimeout customises the maximum duration allowed for snapshot rendering.
 func WithCaptureTimeout(timeout time.Duration) SubscriptionManagerOption {
 	return func(m *SubscriptionManager) {
@@ -100,6 +325,99 @@ func WithDispatchTimeout(timeout time.Duration) SubscriptionManagerOption {
 	}
 }
 
+// WithOnDemandCapture routes CaptureNow through capture instead of the capture used for
+// scheduled deliveries, typically a CapturePool sized separately.
+func WithOnDemandCapture(capture ForecastCapture) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.onDemandCapture = capture
+	}
+}
+
+// WithTextExtractor enables keyword filters. Without it subscriptions are delivered regardless of
+// their keywords.
+func WithTextExtractor(extractor ForecastTextExtractor) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.textExtractor = extractor
+	}
+}
+
+// WithCaptureFeatures makes Add and Update reject subscriptions with options that the capture
+// service reported by source would silently ignore, with a *domain.UnsupportedCaptureError.
+func WithCaptureFeatures(source CaptureFeatureSource) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.features = source
+	}
+}
+
+// WithInitialAlignment sets the alignment used by subscriptions that do not choose one.
+func WithInitialAlignment(alignment domain.Alignment) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if alignment != "" {
+			m.alignment = alignment
+		}
+	}
+}
+
+// WithStaleFallback posts the previous capture, if it is no older than maxAge, when a fresh
+// capture fails. Subscriptions may override maxAge with their own MaxStaleness. Zero disables the
+// fallback for subscriptions without an override.
+func WithStaleFallback(maxAge time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if maxAge > 0 {
+			m.staleFallback = maxAge
+		}
+	}
+}
+
+// WithCaptureRetries makes scheduled deliveries try a failed capture up to attempts times in
+// total, waiting baseDelay before the first retry and doubling the wait after each. All attempts
+// share the capture timeout.
+func WithCaptureRetries(attempts int, baseDelay time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if attempts > 0 {
+			m.captureAttempts = attempts
+		}
+		if baseDelay > 0 {
+			m.retryDelay = baseDelay
+		}
+	}
+}
+
+// WithMaxConsecutiveFailures stops scheduling a subscription after n consecutive failed
+// deliveries. Zero, the default, retries forever.
+func WithMaxConsecutiveFailures(n int) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if n > 0 {
+			m.maxFailures = n
+		}
+	}
+}
+
+// WithFailureAlerts posts a warning to a subscription's own channel once n deliveries in a row
+// have failed, so the channel learns that its source broke. The warning is sent once per streak
+// of failures and requires WithErrorNotices. Zero, the default, disables the warning.
+func WithFailureAlerts(n int) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.alertThreshold = max(n, 0)
+	}
+}
+
+// WithCaptureLimiter makes scheduled deliveries draw on their guild's capture allowance. A run
+// that exceeds it fails at the capture stage with ErrCaptureLimitExceeded.
+func WithCaptureLimiter(limiter CaptureLimiter) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.limiter = limiter
+	}
+}
+
+// WithSettings makes the manager read its timeouts from holder on every delivery, so reloaded
+// values apply without a restart. Zero values fall back to the configured defaults.
+func WithSettings(holder *SettingsHolder) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.settings = holder
+	}
+}
+
 // WithSubscriptionErrorHandler registers the callback used when a dispatch cycle fails.
 func WithSubscriptionErrorHandler(handler SubscriptionErrorHandler) SubscriptionManagerOption {
 	return func(m *SubscriptionManager) {
@@ -109,6 +427,105 @@ func WithSubscriptionErrorHandler(handler SubscriptionErrorHandler) Subscription
 	}
 }
 
+// WithSubscriptionDeliveryHandler registers the callback used when a dispatch cycle succeeds.
+func WithSubscriptionDeliveryHandler(handler SubscriptionDeliveryHandler) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if handler != nil {
+			m.onDelivered = handler
+		}
+	}
+}
+
+// WithDeliveryLagHandler registers the callback reporting how late each scheduled run fired.
+func WithDeliveryLagHandler(handler DeliveryLagHandler) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if handler != nil {
+			m.onDeliveryLag = handler
+		}
+	}
+}
+
+// WithCaptureStatsHandler registers the callback reporting the duration and size of each capture.
+func WithCaptureStatsHandler(handler CaptureStatsHandler) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if handler != nil {
+			m.onCaptured = handler
+		}
+	}
+}
+
+// WithSubscriptionMetrics records subscription creation, removal and the active count.
+func WithSubscriptionMetrics(metrics SubscriptionMetrics) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if metrics != nil {
+			m.metrics = metrics
+		}
+	}
+}
+
+// WithSubscriberNotifier enables first-delivery confirmations for subscriptions that request
+// them.
+func WithSubscriberNotifier(notifier SubscriberNotifier) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.notifier = notifier
+	}
+}
+
+// WithErrorNotices enables failure notices for subscriptions that name an error channel.
+func WithErrorNotices(notifier ChannelNotifier) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.errorNotifier = notifier
+	}
+}
+
+// WithShutdownTimeout bounds how long Shutdown waits for deliveries in progress to finish before
+// cancelling their captures and dispatches.
+func WithShutdownTimeout(timeout time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		if timeout > 0 {
+			m.drainTimeout = timeout
+		}
+	}
+}
+
+// WithLoadConcurrency limits LoadExisting and Start to starting n schedules at a time, each of
+// which records its next delivery in the store, so restoring thousands of subscriptions does not
+// hit the database all at once. Zero or less is unlimited.
+func WithLoadConcurrency(n int) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.loadConcurrency = max(n, 0)
+	}
+}
+
+// WithCatchUpWindow makes LoadExisting deliver a subscription once right away when its recorded
+// next delivery was missed by at most window, e.g. because the bot restarted at 08:05 for an 08:00
+// forecast. The missed delivery is claimed in the store first, so only one of several instances
+// restoring the same subscriptions makes up for it. Zero, the default, skips missed deliveries.
+func WithCatchUpWindow(window time.Duration) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.catchUpWindow = max(window, 0)
+	}
+}
+
+// WithDeliveryLocks makes every scheduled delivery claim its run in locker under instanceID first,
+// skipping runs another instance already claimed. Without it the manager assumes it is the only
+// instance delivering the stored subscriptions.
+func WithDeliveryLocks(locker DeliveryLocker, instanceID string) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.locker = locker
+		m.instanceID = instanceID
+	}
+}
+
+// WithParentContext ties the manager to ctx: the schedule goroutines run under its values, and
+// cancelling it shuts the manager down as Shutdown does, so a caller's signal handling stops every
+// delivery. Without it the manager runs until Shutdown is called.
+func WithParentContext(ctx context.Context) SubscriptionManagerOption {
+	return func(m *SubscriptionManager) {
+		m.parent = ctx
+	}
+}
+
 // WithSubscriptionStore configures persistent storage for subscriptions.
 func WithSubscriptionStore(store SubscriptionStore) SubscriptionManagerOption {
 	return func(m *SubscriptionManager) {
@@ -117,6 +534,7 @@ func WithSubscriptionStore(store SubscriptionStore) SubscriptionManagerOption {
 }
 
 // NewSubscriptionManager builds a manager that captures forecasts via capture and dispatches via sender.
+// Subscriptions may be added and restored right away, but nothing is delivered until Start.
 func NewSubscriptionManager(
 	capture ForecastCapture,
 	sender ForecastSender,
@@ -124,19 +542,35 @@ func NewSubscriptionManager(
 ) *SubscriptionManager {
 	manager := &SubscriptionManager{
 		subscriptions:   make(map[string][]*subscriptionEntry),
+		stopped:         make(map[uint]string),
+		parent:          context.Background(),
+		drainTimeout:    10 * time.Second,
 		capture:         capture,
 		sender:          sender,
 		nowFn:           time.Now,
 		interval:        24 * time.Hour,
+		resyncInterval:  time.Minute,
 		captureTimeout:  30 * time.Second,
 		dispatchTimeout: 30 * time.Second,
+		captureAttempts: 1,
+		retryDelay:      2 * time.Second,
+		alignment:       domain.AlignToWallClock,
 		onError:         func(domain.Subscription, SubscriptionErrorStage, error) {},
+		onDeliveryLag:   func(domain.Subscription, time.Duration) {},
+		onCaptured:      func(domain.Subscription, time.Duration, int, error) {},
+		onDelivered:     func(domain.Subscription) {},
+		metrics:         noopSubscriptionMetrics{},
 	}
 
 	for _, opt := range opts {
 		opt(manager)
 	}
 
+	// Deliveries in progress when the parent is cancelled still get the shutdown timeout to
+	// finish, so runCtx keeps only the parent's values and is cancelled by Shutdown instead.
+	manager.runCtx, manager.cancelRuns = context.WithCancel(context.WithoutCancel(manager.parent))
+	context.AfterFunc(manager.parent, func() { manager.Shutdown() })
+
 	return manager
 }
 
@@ -148,18 +582,57 @@ func (m *SubscriptionManager) Add(sub domain.Subscription) error {
 	if m.sender == nil {
 		return fmt.Errorf("subscription manager missing forecast sender dependency")
 	}
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+	if err := m.checkCaptureFeatures(context.Background(), sub); err != nil {
+		return err
+	}
+
+	firstRun := m.align(&sub)
+
+	reserved := new(domain.Subscription)
+	*reserved = sub
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrManagerClosed
+	}
+	if m.hasDuplicateLocked(sub, nil) {
+		m.mu.Unlock()
+		return ErrDuplicateSubscription
+	}
+	m.adding = append(m.adding, reserved)
+	m.mu.Unlock()
+	// The reservation is dropped once the entry is registered, or when adding it failed.
+	defer func() {
+		m.mu.Lock()
+		m.adding = slices.DeleteFunc(m.adding, func(adding *domain.Subscription) bool {
+			return adding == reserved
+		})
+		m.mu.Unlock()
+	}()
 
 	if m.store != nil {
-		if err := m.store.Create(context.Background(), sub); err != nil {
+		created, err := m.store.Create(context.Background(), sub)
+		if err != nil {
 			return fmt.Errorf("persist subscription: %w", err)
 		}
+		sub = created
 	}
 
-	m.register(sub)
+	if err := m.register(sub, firstRun); err != nil {
+		return err
+	}
+
+	m.metrics.SubscriptionCreated()
 	return nil
 }
 
-// Remove cancels all subscriptions for a channel and returns how many were removed.
+// Remove cancels all subscriptions for a channel and returns how many were removed. With a store,
+// the number of deleted rows is authoritative; a difference from the cancelled schedules that
+// subscriptions stopped after repeated failures do not account for is reported to the error
+// handler.
 func (m *SubscriptionManager) Remove(channelID string) (int, error) {
 	var deletedFromStore int
 	if m.store != nil {
@@ -174,6 +647,15 @@ func (m *SubscriptionManager) Remove(channelID string) (int, error) {
 	entries, ok := m.subscriptions[channelID]
 	if ok {
 		delete(m.subscriptions, channelID)
+		m.active -= len(entries)
+		m.metrics.SetActiveSubscriptions(m.active)
+	}
+	stopped := 0
+	for id, stoppedChannelID := range m.stopped {
+		if stoppedChannelID == channelID {
+			delete(m.stopped, id)
+			stopped++
+		}
 	}
 	m.mu.Unlock()
 
@@ -181,18 +663,98 @@ func (m *SubscriptionManager) Remove(channelID string) (int, error) {
 		close(entry.stopChan)
 	}
 
-	if len(entries) > 0 {
-		return len(entries), nil
+	removed := len(entries)
+	if m.store != nil {
+		removed = deletedFromStore
+		if deletedFromStore != len(entries)+stopped {
+			m.onError(
+				domain.Subscription{ChannelID: channelID},
+				SubscriptionErrorStageReconcile,
+				fmt.Errorf(
+					"deleted %d stored subscriptions but cancelled %d scheduled and %d stopped ones",
+					deletedFromStore,
+					len(entries),
+					stopped,
+				),
+			)
+		}
+	}
+	m.metrics.SubscriptionsRemoved(removed)
+
+	return removed, nil
+}
+
+// Start begins delivering: it launches the schedules of every subscription added or restored so
+// far, at most the load concurrency at a time, and those of subscriptions added later right away.
+// Cancelling ctx shuts the manager down as Shutdown does. Calling Start again has no effect.
+func (m *SubscriptionManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrManagerClosed
+	}
+	if m.started {
+		m.mu.Unlock()
+		return nil
+	}
+	m.started = true
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+
+	context.AfterFunc(ctx, func() { m.Shutdown() })
+
+	var slots chan struct{}
+	if m.loadConcurrency > 0 {
+		slots = make(chan struct{}, m.loadConcurrency)
+	}
+	for i, entry := range pending {
+		select {
+		case <-entry.stopChan:
+			// Removed before it ever ran.
+			m.running.Done()
+			continue
+		default:
+		}
+
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				for range pending[i:] {
+					m.running.Done()
+				}
+				return ctx.Err()
+			}
+			entry.started = func() { <-slots }
+		}
+		go m.schedule(m.runCtx, entry)
 	}
 
-	return deletedFromStore, nil
+	return nil
+}
+
+// Close shuts the manager down as Shutdown does.
+func (m *SubscriptionManager) Close() error {
+	m.Shutdown()
+	return nil
 }
 
-// Shutdown cancels every active subscription. Returns total number cancelled.
+// Shutdown cancels every active subscription and rejects any added afterwards. Deliveries already
+// in progress may finish within the shutdown timeout; after that their captures and dispatches
+// are cancelled. Returns total number cancelled.
 func (m *SubscriptionManager) Shutdown() int {
 	m.mu.Lock()
+	m.closed = true
 	toStop := m.subscriptions
 	m.subscriptions = make(map[string][]*subscriptionEntry)
+	m.active = 0
+	m.metrics.SetActiveSubscriptions(0)
+	// Schedules that never started have nothing to drain.
+	for range m.pending {
+		m.running.Done()
+	}
+	m.pending = nil
 	m.mu.Unlock()
 
 	total := 0
@@ -203,126 +765,1185 @@ func (m *SubscriptionManager) Shutdown() int {
 		}
 	}
 
+	drained := make(chan struct{})
+	go func() {
+		m.running.Wait()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(m.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		m.cancelRuns()
+		<-drained
+	}
+
 	return total
 }
 
-// LoadExisting schedules every subscription currently stored in persistent storage.
+// LoadExisting schedules every subscription currently stored in persistent storage. Subscriptions
+// resume at their recorded next run when it is still ahead.
 func (m *SubscriptionManager) LoadExisting(ctx context.Context) error {
 	if m.store == nil {
 		return nil
 	}
 
-	subs, err := m.store.List(ctx)
-	if err != nil {
-		return fmt.Errorf("load subscriptions: %w", err)
+	var slots chan struct{}
+	if m.loadConcurrency > 0 {
+		slots = make(chan struct{}, m.loadConcurrency)
 	}
 
-	for _, sub := range subs {
-		m.register(sub)
+	for offset := 0; ; offset += loadPageSize {
+		subs, err := m.store.ListPaged(ctx, offset, loadPageSize)
+		if err != nil {
+			return fmt.Errorf("load subscriptions: %w", err)
+		}
+
+		for _, sub := range subs {
+			entry := newSubscriptionEntry(sub, time.Time{})
+			if missed, ok := m.claimMissedRun(ctx, sub); ok {
+				entry.firstRun, entry.catchUp = missed, true
+			} else if sub.NextRunAt.After(m.nowFn()) {
+				// Resume the recorded schedule, as recomputing it from today would shift the phase
+				// of intervals that step on from their previous run or span several days.
+				entry.firstRun = sub.NextRunAt.In(sub.Location())
+			}
+			// Before Start the schedules are only queued, and Start applies the limit itself.
+			if slots != nil && m.isStarted() {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				entry.started = func() { <-slots }
+			}
+			if err := m.start(entry); err != nil {
+				return err
+			}
+		}
+
+		if len(subs) < loadPageSize {
+			return nil
+		}
 	}
+}
 
-	return nil
+// isStarted reports whether Start has been called.
+func (m *SubscriptionManager) isStarted() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.started
 }
 
-// ListByGuild returns every subscription configured for the supplied guild.
-func (m *SubscriptionManager) ListByGuild(
+// claimMissedRun reports the delivery sub missed while the bot was down when it is within the
+// catch-up window and this instance claimed it, by moving the recorded next run on to the regular
+// schedule before another instance could.
+func (m *SubscriptionManager) claimMissedRun(
 	ctx context.Context,
-	guildID string,
-) ([]domain.Subscription, error) {
+	sub domain.Subscription,
+) (time.Time, bool) {
+	now := m.nowFn()
+	missed := sub.NextRunAt
+	if m.catchUpWindow == 0 || missed.IsZero() || !missed.Before(now) ||
+		now.Sub(missed) > m.catchUpWindow {
+		return time.Time{}, false
+	}
+
+	_, timeout := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	claimed, err := m.store.ClaimNextRun(ctx, sub.ID, missed, m.nextRun(sub, now))
+	if err != nil {
+		m.onError(sub, SubscriptionErrorStageSchedule, fmt.Errorf("failed to claim missed run: %w", err))
+		return time.Time{}, false
+	}
+	if !claimed {
+		return time.Time{}, false
+	}
+
+	return missed.In(sub.Location()), true
+}
+
+// FindByID returns the subscription with the supplied ID, or domain.ErrSubscriptionNotFound.
+func (m *SubscriptionManager) FindByID(ctx context.Context, id uint) (domain.Subscription, error) {
 	if m.store != nil {
-		return m.store.ListByGuild(ctx, guildID)
+		return m.store.FindByID(ctx, id)
 	}
 
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var subs []domain.Subscription
 	for _, entries := range m.subscriptions {
 		for _, entry := range entries {
-			if entry.subscription.GuildID == guildID {
-				subs = append(subs, entry.subscription)
+			if entry.subscription.ID == id {
+				return entry.subscription, nil
 			}
 		}
 	}
 
-	return subs, nil
+	return domain.Subscription{}, domain.ErrSubscriptionNotFound
 }
 
-func (m *SubscriptionManager) schedule(entry *subscriptionEntry) {
-	nextRun := m.nextRun(entry.subscription.Time)
-	timer := time.NewTimer(time.Until(nextRun))
-	defer timer.Stop()
+// RemoveOne cancels the subscription at index (counting from 1) in the order returned by
+// ListByChannel for channelID. It reports false when index is out of range.
+func (m *SubscriptionManager) RemoveOne(
+	ctx context.Context,
+	channelID string,
+	index int,
+) (bool, error) {
+	subs, err := m.ListByChannel(ctx, channelID)
+	if err != nil {
+		return false, fmt.Errorf("list subscriptions: %w", err)
+	}
+	if index < 1 || index > len(subs) {
+		return false, nil
+	}
+	target := subs[index-1]
 
-	for {
-		select {
-		case <-timer.C:
-			if err := m.captureAndSend(entry.subscription); err != nil {
-				timer.Reset(m.interval)
-				continue
-			}
-			timer.Reset(m.interval)
-		case <-entry.stopChan:
-			return
+	if m.store != nil {
+		deleted, err := m.store.DeleteByID(ctx, target.ID)
+		if err != nil {
+			return false, fmt.Errorf("delete subscription: %w", err)
+		}
+		if !deleted {
+			return false, nil
 		}
 	}
-}
 
-func (m *SubscriptionManager) register(sub domain.Subscription) {
-	entry := &subscriptionEntry{
-		subscription: sub,
-		stopChan:     make(chan struct{}),
+	if entry := m.entryAt(channelID, index, target.ID); entry != nil {
+		m.unregister(entry)
 	}
-
 	m.mu.Lock()
-	m.subscriptions[sub.ChannelID] = append(m.subscriptions[sub.ChannelID], entry)
+	delete(m.stopped, target.ID)
 	m.mu.Unlock()
+	m.metrics.SubscriptionsRemoved(1)
 
-	go m.schedule(entry)
+	return true, nil
 }
 
-func (m *SubscriptionManager) captureAndSend(sub domain.Subscription) error {
-	ctxCapture, cancelCapture := context.WithTimeout(context.Background(), m.captureTimeout)
-	imageData, err := m.capture.CaptureForecast(ctxCapture, sub.URL, sub.ElementSelector)
-	cancelCapture()
+// Update replaces the subscription at index (counting from 1) in the order returned by
+// ListByChannel for channelID with sub, keeping its ID and channel, and reschedules it from the
+// new values. It returns domain.ErrSubscriptionNotFound when index is out of range.
+func (m *SubscriptionManager) Update(
+	ctx context.Context,
+	channelID string,
+	index int,
+	sub domain.Subscription,
+) error {
+	subs, err := m.ListByChannel(ctx, channelID)
 	if err != nil {
-		m.onError(
-			sub,
-			SubscriptionErrorStageCapture,
-			fmt.Errorf("failed to capture forecast: %w", err),
-		)
-		return err
+		return fmt.Errorf("list subscriptions: %w", err)
+	}
+	if index < 1 || index > len(subs) {
+		return domain.ErrSubscriptionNotFound
 	}
+	sub.ID = subs[index-1].ID
+	sub.ChannelID = channelID
 
-	ctxSend, cancelSend := context.WithTimeout(context.Background(), m.dispatchTimeout)
-	defer cancelSend()
-	if err := m.sender.SendForecast(ctxSend, sub.ChannelID, imageData, sub.Message); err != nil {
-		m.onError(
-			sub,
-			SubscriptionErrorStageDispatch,
-			fmt.Errorf("failed to dispatch forecast: %w", err),
-		)
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+	if err := m.checkCaptureFeatures(ctx, sub); err != nil {
 		return err
 	}
 
+	entry := m.entryAt(channelID, index, sub.ID)
+	m.mu.RLock()
+	duplicate := m.hasDuplicateLocked(sub, entry)
+	m.mu.RUnlock()
+	if duplicate {
+		return ErrDuplicateSubscription
+	}
+
+	if m.store != nil {
+		if err := m.store.UpdateByID(ctx, sub); err != nil {
+			return fmt.Errorf("update subscription: %w", err)
+		}
+	}
+
+	if entry == nil {
+		return nil
+	}
+	return m.swap(entry, sub)
+}
+
+// entryAt returns the scheduled entry listed at index by ListByChannel, identified by id when a
+// store assigns IDs. Without a store the listing is the entries themselves, so the position
+// identifies the entry.
+func (m *SubscriptionManager) entryAt(channelID string, index int, id uint) *subscriptionEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for position, entry := range m.subscriptions[channelID] {
+		if (m.store == nil && position == index-1) ||
+			(m.store != nil && entry.subscription.ID == id) {
+			return entry
+		}
+	}
+
 	return nil
 }
 
-func (m *SubscriptionManager) nextRun(target time.Time) time.Time {
+// ListStatusByChannel returns the subscriptions currently scheduled for channelID with their next
+// run and failure count, ordered by ID. It reads the live timers rather than the store, so
+// subscriptions stopped after repeated failures are absent.
+func (m *SubscriptionManager) ListStatusByChannel(
+	_ context.Context,
+	channelID string,
+) ([]SubscriptionStatus, error) {
+	m.mu.RLock()
+	statuses := make([]SubscriptionStatus, 0, len(m.subscriptions[channelID]))
+	for _, entry := range m.subscriptions[channelID] {
+		status := SubscriptionStatus{
+			Subscription:        entry.subscription,
+			ConsecutiveFailures: int(entry.failures.Load()),
+		}
+		status.Paused = entry.paused.Load()
+		if nextRun := entry.nextRun.Load(); nextRun != 0 {
+			status.NextRun = time.Unix(0, nextRun)
+		}
+		statuses = append(statuses, status)
+	}
+	m.mu.RUnlock()
+
+	slices.SortStableFunc(statuses, func(a, b SubscriptionStatus) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return statuses, nil
+}
+
+// ListByChannel returns every subscription configured for the supplied channel.
+func (m *SubscriptionManager) ListByChannel(
+	ctx context.Context,
+	channelID string,
+) ([]domain.Subscription, error) {
+	if m.store != nil {
+		return m.store.ListByChannel(ctx, channelID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subs := make([]domain.Subscription, 0, len(m.subscriptions[channelID]))
+	for _, entry := range m.subscriptions[channelID] {
+		sub := entry.subscription
+		sub.Paused = entry.paused.Load()
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+// SetPaused pauses or resumes the channel's subscription numbered index in ListByChannel, or all
+// of them when index is zero, and returns how many changed. Paused subscriptions stay scheduled
+// but skip their deliveries. It returns domain.ErrSubscriptionNotFound when there is no such
+// subscription.
+func (m *SubscriptionManager) SetPaused(
+	ctx context.Context,
+	channelID string,
+	index int,
+	paused bool,
+) (int, error) {
+	subs, err := m.ListByChannel(ctx, channelID)
+	if err != nil {
+		return 0, fmt.Errorf("list subscriptions: %w", err)
+	}
+	if len(subs) == 0 || index < 0 || index > len(subs) {
+		return 0, domain.ErrSubscriptionNotFound
+	}
+
+	changed := 0
+	for position, sub := range subs {
+		if (index != 0 && position != index-1) || sub.Paused == paused {
+			continue
+		}
+		if m.store != nil {
+			if err := m.store.UpdatePaused(ctx, sub.ID, paused); err != nil {
+				return changed, fmt.Errorf("update subscription: %w", err)
+			}
+		}
+		if entry := m.entryAt(channelID, position+1, sub.ID); entry != nil {
+			entry.paused.Store(paused)
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// TransferOwnership makes userID the owner of the subscription with the supplied ID and returns
+// the updated subscription.
+func (m *SubscriptionManager) TransferOwnership(
+	ctx context.Context,
+	id uint,
+	userID string,
+) (domain.Subscription, error) {
+	sub, err := m.FindByID(ctx, id)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	if m.store != nil {
+		if err := m.store.UpdateOwner(ctx, id, userID); err != nil {
+			return domain.Subscription{}, fmt.Errorf("update subscription owner: %w", err)
+		}
+	}
+
+	sub.CreatedByUserID = userID
+	if err := m.replace(sub); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	return sub, nil
+}
+
+// CaptureNow captures sub exactly as its next scheduled delivery would, without posting it. The
+// capture draws on the guild's allowance when a limiter is configured.
+func (m *SubscriptionManager) CaptureNow(
+	ctx context.Context,
+	sub domain.Subscription,
+) ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.captureTimeoutFor(sub))
+	defer cancel()
+
+	capture := m.capture
+	if m.onDemandCapture != nil {
+		capture = m.onDemandCapture
+	}
+	return m.captureImages(ctx, capture, sub)
+}
+
+// DeliveryMessage returns sub's message with its placeholders expanded as a delivery made now
+// would expand them, for posting alongside CaptureNow's images.
+func (m *SubscriptionManager) DeliveryMessage(sub domain.Subscription) string {
+	now := m.nowFn()
+	local := now.In(sub.Location())
+	return domain.ExpandMessage(sub.Message, local, m.resolveTarget(sub).URL)
+}
+
+// ListByGuild returns every subscription configured for the supplied guild.
+func (m *SubscriptionManager) ListByGuild(
+	ctx context.Context,
+	guildID string,
+) ([]domain.Subscription, error) {
+	if m.store != nil {
+		return m.store.ListByGuild(ctx, guildID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var subs []domain.Subscription
+	for _, entries := range m.subscriptions {
+		for _, entry := range entries {
+			if entry.subscription.GuildID == guildID {
+				subs = append(subs, entry.subscription)
+			}
+		}
+	}
+
+	return subs, nil
+}
+
+// CountByGuild returns how many subscriptions are configured for the supplied guild.
+func (m *SubscriptionManager) CountByGuild(ctx context.Context, guildID string) (int, error) {
+	if m.store != nil {
+		return m.store.CountByGuild(ctx, guildID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for _, entries := range m.subscriptions {
+		for _, entry := range entries {
+			if entry.subscription.GuildID == guildID {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// schedule runs the delivery loop for entry. The next run is always recomputed from the wall clock
+// and the timer never sleeps longer than the resync interval, so deliveries stay aligned even when
+// the system clock is stepped or the host is suspended.
+func (m *SubscriptionManager) schedule(ctx context.Context, entry *subscriptionEntry) {
+	defer m.running.Done()
+
+	scheduled := entry.firstRun
+	if scheduled.IsZero() {
+		scheduled = m.nextRun(entry.subscription, m.nowFn())
+	} else if !entry.catchUp && !entry.subscription.Days.Contains(scheduled.Weekday()) {
+		scheduled = m.nextRun(entry.subscription, scheduled)
+	}
+	if entry.catchUp {
+		entry.nextRun.Store(scheduled.UnixNano())
+	} else {
+		m.recordNextRun(ctx, entry, scheduled)
+	}
+	if entry.started != nil {
+		entry.started()
+	}
+	timer := time.NewTimer(m.waitUntil(scheduled))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			now := m.nowFn()
+			if now.Before(scheduled) {
+				timer.Reset(m.waitUntil(scheduled))
+				continue
+			}
+
+			// Paused subscriptions and runs another instance claimed only move on to the next run.
+			if entry.paused.Load() || !m.claimDelivery(ctx, entry, scheduled) {
+				scheduled = m.followingRun(entry.subscription, scheduled, now)
+				m.recordNextRun(ctx, entry, scheduled)
+				timer.Reset(m.waitUntil(scheduled))
+				continue
+			}
+
+			m.onDeliveryLag(entry.subscription, now.Sub(scheduled))
+			if err := m.captureAndSend(ctx, entry); err != nil {
+				m.noticeFailure(ctx, entry, err)
+				failures := entry.failures.Add(1)
+				if failures == 1 {
+					entry.failingSince = scheduled
+				}
+				m.alertFailureStreak(ctx, entry, int(failures))
+				if m.maxFailures > 0 && int(failures) >= m.maxFailures {
+					m.abort(entry, err)
+					return
+				}
+			} else {
+				entry.failures.Store(0)
+			}
+
+			after := m.nowFn()
+			if after.Before(scheduled) {
+				after = scheduled
+			}
+			scheduled = m.followingRun(entry.subscription, scheduled, after)
+			m.recordNextRun(ctx, entry, scheduled)
+			timer.Reset(m.waitUntil(scheduled))
+		case <-entry.stopChan:
+			return
+		}
+	}
+}
+
+// claimDelivery reports whether this instance makes entry's delivery scheduled for scheduled. It is
+// always true without a delivery locker, and when the claim itself fails, since a duplicate
+// forecast is preferable to a missed one.
+func (m *SubscriptionManager) claimDelivery(
+	ctx context.Context,
+	entry *subscriptionEntry,
+	scheduled time.Time,
+) bool {
+	sub := entry.subscription
+	if m.locker == nil || sub.ID == 0 {
+		return true
+	}
+
+	_, timeout := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	claimed, err := m.locker.ClaimDelivery(ctx, sub.ID, scheduled, m.instanceID)
+	if err != nil {
+		m.onError(sub, SubscriptionErrorStageSchedule, fmt.Errorf("failed to claim delivery: %w", err))
+		return true
+	}
+	return claimed
+}
+
+// abort stops scheduling entry after its last failure, err. The subscription is kept in the store
+// so it is scheduled again after a restart, once the source has been investigated.
+func (m *SubscriptionManager) abort(entry *subscriptionEntry, err error) {
+	if m.unregister(entry) && entry.subscription.ID != 0 {
+		m.mu.Lock()
+		m.stopped[entry.subscription.ID] = entry.subscription.ChannelID
+		m.mu.Unlock()
+	}
+	m.onError(
+		entry.subscription,
+		SubscriptionErrorStageAbort,
+		fmt.Errorf("stopped after %d consecutive failures: %w", entry.failures.Load(), err),
+	)
+}
+
+// recordNextRun persists when entry's subscription is next delivered, so the store can answer
+// which subscriptions are due in a window.
+func (m *SubscriptionManager) recordNextRun(
+	ctx context.Context,
+	entry *subscriptionEntry,
+	scheduled time.Time,
+) {
+	entry.nextRun.Store(scheduled.UnixNano())
+
+	sub := entry.subscription
+	if m.store == nil || sub.ID == 0 {
+		return
+	}
+
+	_, timeout := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := m.store.UpdateNextRun(ctx, sub.ID, scheduled); err != nil {
+		m.onError(
+			sub,
+			SubscriptionErrorStageSchedule,
+			fmt.Errorf("failed to record next run: %w", err),
+		)
+	}
+}
+
+// waitUntil returns how long to sleep before re-checking the wall clock against scheduled.
+func (m *SubscriptionManager) waitUntil(scheduled time.Time) time.Duration {
+	wait := scheduled.Sub(m.nowFn())
+	if wait > m.resyncInterval {
+		return m.resyncInterval
+	}
+	return wait
+}
+
+// align resolves sub's alignment and, for creation-aligned subscriptions, returns the instant of
+// the first delivery after rewriting sub.Time to its time of day so later runs (and restarts)
+// repeat from it. Wall-clock subscriptions yield the zero time.
+func (m *SubscriptionManager) align(sub *domain.Subscription) time.Time {
+	if sub.Alignment == "" {
+		sub.Alignment = m.alignment
+	}
+	if sub.Alignment != domain.AlignToCreation {
+		return time.Time{}
+	}
+
 	now := m.nowFn()
-	scheduled := time.Date(
-		now.Year(),
-		now.Month(),
-		now.Day(),
-		target.Hour(),
-		target.Minute(),
+	first := now.Add(sub.StartDelay).In(sub.Location())
+	sub.Time = time.Date(
+		0,
+		time.January,
+		1,
+		first.Hour(),
+		first.Minute(),
+		first.Second(),
 		0,
+		time.UTC,
+	)
+	return first
+}
+
+// hasDuplicateLocked reports whether sub's channel already schedules, or is about to schedule, a
+// delivery of the same URL and selector at the same time of day, ignoring except, the entry sub
+// replaces when it is being edited. Callers must hold m.mu.
+func (m *SubscriptionManager) hasDuplicateLocked(
+	sub domain.Subscription,
+	except *subscriptionEntry,
+) bool {
+	for _, entry := range m.subscriptions[sub.ChannelID] {
+		if entry != except && sameDelivery(entry.subscription, sub) {
+			return true
+		}
+	}
+	for _, adding := range m.adding {
+		if sameDelivery(*adding, sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameDelivery reports whether a and b deliver the same URL and selector to the same channel at
+// the same time of day.
+func sameDelivery(a, b domain.Subscription) bool {
+	aHour, aMinute, aSecond := a.Time.Clock()
+	bHour, bMinute, bSecond := b.Time.Clock()
+	return a.ChannelID == b.ChannelID &&
+		aHour == bHour && aMinute == bMinute && aSecond == bSecond &&
+		a.URL == b.URL &&
+		a.ElementSelector == b.ElementSelector
+}
+
+// register starts scheduling sub.
+func (m *SubscriptionManager) register(sub domain.Subscription, firstRun time.Time) error {
+	return m.start(newSubscriptionEntry(sub, firstRun))
+}
+
+func newSubscriptionEntry(sub domain.Subscription, firstRun time.Time) *subscriptionEntry {
+	entry := &subscriptionEntry{
+		subscription: sub,
+		stopChan:     make(chan struct{}),
+		firstRun:     firstRun,
+	}
+	entry.paused.Store(sub.Paused)
+	return entry
+}
+
+// start inserts entry and launches its schedule goroutine, or queues it until Start. The closed
+// check and insertion share one critical section so a concurrent Shutdown can never miss the new
+// entry.
+func (m *SubscriptionManager) start(entry *subscriptionEntry) error {
+	sub := entry.subscription
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrManagerClosed
+	}
+	m.subscriptions[sub.ChannelID] = append(m.subscriptions[sub.ChannelID], entry)
+	m.active++
+	m.metrics.SetActiveSubscriptions(m.active)
+	m.running.Add(1)
+	if !m.started {
+		m.pending = append(m.pending, entry)
+		m.mu.Unlock()
+		return nil
+	}
+	m.mu.Unlock()
+
+	go m.schedule(m.runCtx, entry)
+	return nil
+}
+
+// replace swaps the scheduled entry holding sub's ID for one holding sub. Entries are never
+// modified in place because their schedule goroutine reads them without locking.
+func (m *SubscriptionManager) replace(sub domain.Subscription) error {
+	m.mu.RLock()
+	var current *subscriptionEntry
+	for _, entries := range m.subscriptions {
+		for _, entry := range entries {
+			if entry.subscription.ID == sub.ID {
+				current = entry
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	if current == nil {
+		return nil
+	}
+	return m.swap(current, sub)
+}
+
+// swap stops current and schedules sub in its place, keeping whether the first delivery has
+// already been confirmed. It does nothing when current was removed in the meantime.
+func (m *SubscriptionManager) swap(current *subscriptionEntry, sub domain.Subscription) error {
+	if !m.unregister(current) {
+		return nil
+	}
+
+	entry := newSubscriptionEntry(sub, time.Time{})
+	entry.confirmed.Store(current.confirmed.Load())
+	return m.start(entry)
+}
+
+// unregister removes a single entry and stops its schedule. The channel's key is deleted once its
+// last entry is gone, so channels that come and go never leave empty slices behind. It reports
+// whether entry was still registered.
+func (m *SubscriptionManager) unregister(entry *subscriptionEntry) bool {
+	channelID := entry.subscription.ChannelID
+
+	m.mu.Lock()
+	entries := m.subscriptions[channelID]
+	index := slices.Index(entries, entry)
+	if index < 0 {
+		m.mu.Unlock()
+		return false
+	}
+	if remaining := slices.Delete(entries, index, index+1); len(remaining) > 0 {
+		m.subscriptions[channelID] = remaining
+	} else {
+		delete(m.subscriptions, channelID)
+	}
+	m.active--
+	m.metrics.SetActiveSubscriptions(m.active)
+	m.mu.Unlock()
+
+	close(entry.stopChan)
+	return true
+}
+
+func (m *SubscriptionManager) captureAndSend(ctx context.Context, entry *subscriptionEntry) error {
+	sub := entry.subscription
+	_, dispatchTimeout := m.timeouts()
+	captureTimeout := m.captureTimeoutFor(sub)
+	sourceURL := m.resolveTarget(sub).URL
+	delivery := domain.Delivery{
+		ChannelID:        sub.ChannelID,
+		Format:           sub.Format,
+		Message:          m.DeliveryMessage(sub),
+		SourceURL:        sourceURL,
+		ReplyToMessageID: sub.ReplyToMessageID,
+		WebhookURL:       sub.WebhookURL,
+	}
+
+	if len(sub.Keywords) > 0 && m.textExtractor != nil {
+		ctxText, cancelText := context.WithTimeout(ctx, captureTimeout)
+		matched, err := m.matchesKeywords(ctxText, sub)
+		cancelText()
+		switch {
+		case errors.Is(err, domain.ErrTextExtractionUnsupported):
+			// The filter cannot be evaluated, so deliver as if it were not configured.
+		case err != nil:
+			m.onError(
+				sub,
+				SubscriptionErrorStageCapture,
+				fmt.Errorf("failed to extract forecast text: %w", err),
+			)
+			return err
+		case !matched:
+			return nil
+		}
+	}
+
+	ctxCapture, cancelCapture := context.WithTimeout(ctx, captureTimeout)
+	images, err := m.captureWithRetries(ctxCapture, entry)
+	cancelCapture()
+	if err != nil {
+		m.onError(
+			sub,
+			SubscriptionErrorStageCapture,
+			fmt.Errorf("failed to capture forecast: %w", err),
+		)
+
+		maxAge := m.maxStalenessFor(sub)
+		if maxAge == 0 || entry.lastImages == nil || m.nowFn().Sub(entry.lastCapturedAt) > maxAge {
+			return err
+		}
+		delivery.Images = entry.lastImages
+		delivery.FallbackCapturedAt = entry.lastCapturedAt
+	} else {
+		delivery.Images = images
+		if m.maxStalenessFor(sub) > 0 {
+			entry.lastImages, entry.lastCapturedAt = images, m.nowFn()
+		}
+	}
+
+	// The capture is reused for every target channel; the reply anchor and webhook only exist in
+	// the primary channel.
+	var (
+		firstErr  error
+		delivered bool
+	)
+	for _, channelID := range sub.TargetChannelIDs() {
+		target := delivery
+		target.ChannelID = channelID
+		if channelID != sub.ChannelID {
+			target.ReplyToMessageID = ""
+			target.WebhookURL = ""
+		}
+
+		if err := m.dispatch(ctx, sub, target, dispatchTimeout); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered {
+		m.onDelivered(sub)
+		m.confirmFirstDelivery(ctx, entry, dispatchTimeout)
+	}
+	return firstErr
+}
+
+// confirmFirstDelivery tells the owner of entry's subscription that it delivered successfully,
+// when they asked to be told. The request is cleared from the store before the message is sent so
+// a restart never repeats it; failures are reported but do not affect the delivery.
+func (m *SubscriptionManager) confirmFirstDelivery(
+	ctx context.Context,
+	entry *subscriptionEntry,
+	timeout time.Duration,
+) {
+	sub := entry.subscription
+	if !sub.ConfirmFirstDelivery || entry.confirmed.Load() || m.notifier == nil ||
+		sub.CreatedByUserID == "" {
+		return
+	}
+	entry.confirmed.Store(true)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if m.store != nil {
+		if err := m.store.ClearFirstDeliveryConfirmation(ctx, sub.ID); err != nil {
+			m.onError(
+				sub,
+				SubscriptionErrorStageConfirmation,
+				fmt.Errorf("failed to record first delivery confirmation: %w", err),
+			)
+		}
+	}
+
+	message := fmt.Sprintf(
+		"✅ Your weather subscription **%s** delivered its first forecast to <#%s>. "+
+			"Everything is set up!",
+		sub.DisplayLabel(),
+		sub.ChannelID,
+	)
+	if err := m.notifier.NotifyUser(ctx, sub.CreatedByUserID, message); err != nil {
+		m.onError(
+			sub,
+			SubscriptionErrorStageConfirmation,
+			fmt.Errorf("failed to confirm first delivery to user %s: %w", sub.CreatedByUserID, err),
+		)
+	}
+}
+
+// noticeFailure posts err to the error channel of entry's subscription, at most once per
+// errorNoticeInterval. The global error handler has already been called.
+func (m *SubscriptionManager) noticeFailure(
+	ctx context.Context,
+	entry *subscriptionEntry,
+	err error,
+) {
+	sub := entry.subscription
+	if sub.ErrorNotifyChannelID == "" || m.errorNotifier == nil {
+		return
+	}
+	now := m.nowFn()
+	if !entry.lastErrorNotice.IsZero() && now.Sub(entry.lastErrorNotice) < errorNoticeInterval {
+		return
+	}
+	entry.lastErrorNotice = now
+
+	_, dispatchTimeout := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, dispatchTimeout)
+	defer cancel()
+
+	message := fmt.Sprintf(
+		"⚠️ Weather subscription **%s** (#%d) for <#%s> failed: %v",
+		sub.DisplayLabel(),
+		sub.ID,
+		sub.ChannelID,
+		err,
+	)
+	channelID := sub.ErrorNotifyChannelID
+	if notifyErr := m.errorNotifier.NotifyChannel(ctx, channelID, message); notifyErr != nil {
+		m.onError(
+			sub,
+			SubscriptionErrorStageDispatch,
+			fmt.Errorf("failed to post failure notice to channel %s: %w", channelID, notifyErr),
+		)
+	}
+}
+
+// alertFailureStreak warns entry's channel when its streak of failed deliveries reaches the alert
+// threshold. Later failures in the same streak are not announced again.
+func (m *SubscriptionManager) alertFailureStreak(
+	ctx context.Context,
+	entry *subscriptionEntry,
+	failures int,
+) {
+	if m.alertThreshold == 0 || failures != m.alertThreshold || m.errorNotifier == nil {
+		return
+	}
+
+	_, dispatchTimeout := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, dispatchTimeout)
+	defer cancel()
+
+	sub := entry.subscription
+	message := fmt.Sprintf(
+		"⚠️ Couldn't fetch the forecast **%s** from <%s> since <t:%d:R> "+
+			"(%d deliveries in a row). The page may have changed; try `/validate id:%d`.",
+		sub.DisplayLabel(),
+		m.resolveTarget(sub).URL,
+		entry.failingSince.Unix(),
+		failures,
+		sub.ID,
+	)
+	if err := m.errorNotifier.NotifyChannel(ctx, sub.ChannelID, message); err != nil {
+		m.onError(
+			sub,
+			SubscriptionErrorStageDispatch,
+			fmt.Errorf("failed to post failure alert to channel %s: %w", sub.ChannelID, err),
+		)
+	}
+}
+
+// dispatch sends delivery within timeout and reports failures for sub. Partial deliveries, and
+// deliveries only a secondary sender failed, count as sent.
+func (m *SubscriptionManager) dispatch(
+	ctx context.Context,
+	sub domain.Subscription,
+	delivery domain.Delivery,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	m.metrics.DispatchAttempted()
+	err := m.sender.SendForecast(ctx, delivery)
+	if err == nil {
+		return nil
+	}
+
+	var partial *PartialDeliveryError
+	var secondary *SecondaryDeliveryError
+	if errors.As(err, &partial) || errors.As(err, &secondary) {
+		m.onError(sub, SubscriptionErrorStageDispatch, err)
+		return nil
+	}
+
+	m.onError(
+		sub,
+		SubscriptionErrorStageDispatch,
+		fmt.Errorf("failed to dispatch forecast to channel %s: %w", delivery.ChannelID, err),
+	)
+	return err
+}
+
+// captureImages captures every forecast day of sub.
+func (m *SubscriptionManager) captureImages(
+	ctx context.Context,
+	capture ForecastCapture,
+	sub domain.Subscription,
+) ([][]byte, error) {
+	requests := m.resolveTarget(sub).CaptureRequests(m.nowFn())
+	if m.limiter != nil && !m.limiter.Allow(sub.GuildID, len(requests)) {
+		m.metrics.CaptureRateLimited()
+		return nil, ErrCaptureLimitExceeded
+	}
+
+	images := make([][]byte, 0, len(requests))
+	for _, req := range requests {
+		m.metrics.CaptureAttempted()
+		started := time.Now()
+		imageData, err := capture.CaptureForecast(ctx, req)
+		if err == nil && len(imageData) < minCaptureSize {
+			err = fmt.Errorf("%w: %d bytes from %s", ErrEmptyCapture, len(imageData), req.URL)
+		}
+		m.onCaptured(sub, time.Since(started), len(imageData), err)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, imageData)
+	}
+
+	return images, nil
+}
+
+// captureWithRetries captures entry's subscription, retrying failed attempts with exponential
+// backoff while ctx allows. Rate-limited captures are not retried, and waiting stops as soon as
+// the entry is unregistered, e.g. by Shutdown.
+func (m *SubscriptionManager) captureWithRetries(
+	ctx context.Context,
+	entry *subscriptionEntry,
+) ([][]byte, error) {
+	delay := m.retryDelay
+	for attempt := 1; ; attempt++ {
+		images, err := m.captureImages(ctx, m.capture, entry.subscription)
+		var unsupported *domain.UnsupportedCaptureError
+		if err == nil || attempt >= m.captureAttempts || ctx.Err() != nil ||
+			errors.Is(err, ErrCaptureLimitExceeded) || errors.As(err, &unsupported) {
+			return images, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-entry.stopChan:
+			timer.Stop()
+			return nil, err
+		}
+		delay *= 2
+	}
+}
+
+// checkCaptureFeatures returns a *domain.UnsupportedCaptureError when sub needs features the
+// capture service lacks. Subscriptions are accepted when the service cannot be asked; their
+// captures then fail with the same error once it answers.
+func (m *SubscriptionManager) checkCaptureFeatures(
+	ctx context.Context,
+	sub domain.Subscription,
+) error {
+	required := sub.CaptureFeatures()
+	if m.features == nil || len(required) == 0 {
+		return nil
+	}
+
+	captureTimeout, _ := m.timeouts()
+	ctx, cancel := context.WithTimeout(ctx, captureTimeout)
+	defer cancel()
+	features, err := m.features.CaptureFeatures(ctx)
+	if err != nil {
+		return nil
+	}
+	return features.Check(required)
+}
+
+// matchesKeywords reports whether the text of any forecast day of sub contains one of its
+// keywords.
+func (m *SubscriptionManager) matchesKeywords(
+	ctx context.Context,
+	sub domain.Subscription,
+) (bool, error) {
+	for _, req := range m.resolveTarget(sub).CaptureRequests(m.nowFn()) {
+		text, err := m.textExtractor.ExtractForecastText(ctx, req)
+		if err != nil {
+			return false, err
+		}
+		if domain.MatchesKeywords(text, sub.Keywords) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// maxStalenessFor returns how old a fallback capture for sub may be. Zero disables the fallback.
+func (m *SubscriptionManager) maxStalenessFor(sub domain.Subscription) time.Duration {
+	if sub.MaxStaleness > 0 {
+		return sub.MaxStaleness
+	}
+	return m.staleFallback
+}
+
+// captureTimeoutFor returns how long a capture of sub may take: its own timeout, or the one
+// currently in effect for every subscription.
+func (m *SubscriptionManager) captureTimeoutFor(sub domain.Subscription) time.Duration {
+	if sub.CaptureTimeout > 0 {
+		return sub.CaptureTimeout
+	}
+	timeout, _ := m.timeouts()
+	return timeout
+}
+
+// timeouts returns the capture and dispatch timeouts currently in effect.
+func (m *SubscriptionManager) timeouts() (time.Duration, time.Duration) {
+	captureTimeout, dispatchTimeout := m.captureTimeout, m.dispatchTimeout
+	if m.settings != nil {
+		settings := m.settings.Load()
+		if settings.CaptureTimeout > 0 {
+			captureTimeout = settings.CaptureTimeout
+		}
+		if settings.DispatchTimeout > 0 {
+			dispatchTimeout = settings.DispatchTimeout
+		}
+	}
+
+	return captureTimeout, dispatchTimeout
+}
+
+// resolveTarget returns sub with its URL replaced by the current latest forecast page when the
+// subscription tracks the latest forecast. Fixed subscriptions are returned unchanged.
+func (m *SubscriptionManager) resolveTarget(sub domain.Subscription) domain.Subscription {
+	if sub.Mode.OrDefault() != domain.ForecastModeLatest || m.settings == nil {
+		return sub
+	}
+	if latest := m.settings.Load().LatestForecastURL; latest != "" {
+		sub.URL = latest
+	}
+	return sub
+}
+
+func (m *SubscriptionManager) intervalFor(sub domain.Subscription) time.Duration {
+	if sub.EveryN > 0 {
+		return sub.EveryN
+	}
+	return m.interval
+}
+
+// nextRun returns the first interval boundary after now, anchored to the subscription's time of day
+// in its timezone (UTC when it has none), skipping days the subscription is not delivered on.
+// Boundaries repeat every interval in both directions from today's anchor, so a 6h subscription at
+// 20:00 fires next at 02:00, 08:00 or 14:00 as appropriate rather than jumping to 20:00. The
+// division truncates toward zero, which for an anchor later than now lands on the first boundary
+// at or after now; the loop then steps past now itself.
+// Intervals of whole days step by calendar days instead, so a daily delivery keeps its wall-clock
+// time when daylight saving time starts or ends, and skipped days move on to the next day.
+func (m *SubscriptionManager) nextRun(sub domain.Subscription, now time.Time) time.Time {
+	// A jittered schedule is the plain schedule shifted later, so find the plain boundary after
+	// the equally shifted instant.
+	jitter := m.jitterFor(sub)
+	now = now.Add(-jitter)
+
+	interval := m.intervalFor(sub)
+	location := sub.Location()
+	local := now.In(location)
+	anchor := time.Date(
+		local.Year(),
+		local.Month(),
+		local.Day(),
+		sub.Time.Hour(),
+		sub.Time.Minute(),
+		sub.Time.Second(),
 		0,
-		now.Location(),
+		location,
 	)
 
-	if scheduled.After(now) {
-		return scheduled
+	if interval%(24*time.Hour) == 0 {
+		days := int(interval / (24 * time.Hour))
+		scheduled := anchor
+		for !scheduled.After(now) {
+			scheduled = scheduled.AddDate(0, 0, days)
+		}
+		for !sub.Days.Contains(scheduled.Weekday()) {
+			scheduled = scheduled.AddDate(0, 0, 1)
+		}
+		return scheduled.Add(jitter)
+	}
+
+	scheduled := anchor.Add(now.Sub(anchor) / interval * interval)
+	for !scheduled.After(now) {
+		scheduled = scheduled.Add(interval)
+	}
+	for !sub.Days.Contains(scheduled.Weekday()) {
+		scheduled = scheduled.Add(interval)
+	}
+
+	return scheduled.Add(jitter)
+}
+
+// followingRun returns the run after previous that follows now. Intervals that divide a day or span
+// whole days are realigned to the subscription's time of day by nextRun; any other interval steps
+// on from previous, since realigning it to today's anchor would shorten the gap across midnight.
+func (m *SubscriptionManager) followingRun(
+	sub domain.Subscription,
+	previous time.Time,
+	now time.Time,
+) time.Time {
+	interval := m.intervalFor(sub)
+	if (24*time.Hour)%interval == 0 || interval%(24*time.Hour) == 0 {
+		return m.nextRun(sub, now)
+	}
+
+	jitter := m.jitterFor(sub)
+	scheduled := previous.Add(-jitter).In(sub.Location())
+	for !scheduled.After(now.Add(-jitter)) {
+		scheduled = scheduled.Add(interval)
+	}
+	for !sub.Days.Contains(scheduled.Weekday()) {
+		scheduled = scheduled.Add(interval)
+	}
+
+	return scheduled.Add(jitter)
+}
+
+// jitterFor returns sub's delivery offset within the schedule jitter. It is derived from the
+// channel and time of day, so a subscription keeps the same offset every day and across restarts
+// while subscriptions sharing a popular time are spread out.
+func (m *SubscriptionManager) jitterFor(sub domain.Subscription) time.Duration {
+	if m.jitter <= 0 {
+		return 0
 	}
 
-	return scheduled.Add(m.interval)
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%s|%s", sub.ChannelID, sub.Time.Format(time.TimeOnly))
+	return time.Duration(hash.Sum64() % uint64(m.jitter))
 }
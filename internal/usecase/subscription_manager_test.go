@@ -0,0 +1,1480 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+	"github.com/sglre6355/weather-lady/internal/usecase/usecasetest"
+)
+
+// testImage is a capture large enough to pass the manager's empty capture check.
+var testImage = make([]byte, 128)
+
+func newTestManager(
+	t *testing.T,
+	opts ...usecase.SubscriptionManagerOption,
+) (*usecase.SubscriptionManager, *usecasetest.FakeCapture, *usecasetest.FakeSender) {
+	t.Helper()
+
+	capture := &usecasetest.FakeCapture{Image: testImage}
+	sender := usecasetest.NewFakeSender(16)
+	manager := usecase.NewSubscriptionManager(capture, sender, opts...)
+	t.Cleanup(func() { manager.Shutdown() })
+	return manager, capture, sender
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return location
+}
+
+func timeOfDay(hour, minute int) time.Time {
+	return time.Date(0, time.January, 1, hour, minute, 0, 0, time.UTC)
+}
+
+// waitFor polls condition until it holds, failing the test after a few seconds.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForNextRun waits until every schedule of channelID has computed its next delivery, so a
+// FakeClock advanced afterwards moves past it.
+func waitForNextRun(t *testing.T, manager *usecase.SubscriptionManager, channelID string) {
+	t.Helper()
+
+	waitFor(t, "the next run of "+channelID, func() bool {
+		statuses, _ := manager.ListStatusByChannel(context.Background(), channelID)
+		for _, status := range statuses {
+			if status.NextRun.IsZero() {
+				return false
+			}
+		}
+		return len(statuses) > 0
+	})
+}
+
+// receive returns the next value from ch, failing the test after a few seconds.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(5 * time.Second):
+		var zero T
+		t.Fatalf("timed out waiting for %T", zero)
+		return zero
+	}
+}
+
+// testSubscription returns a valid subscription for channelID delivered daily at hour:00.
+func testSubscription(channelID string, hour int) domain.Subscription {
+	return domain.Subscription{
+		ChannelID:       channelID,
+		GuildID:         "guild",
+		Time:            timeOfDay(hour, 0),
+		URL:             "https://example.com/forecast",
+		ElementSelector: "#forecast",
+		Message:         "Today's forecast",
+	}
+}
+
+// blockingCapture is a ForecastCapture that blocks until its context is done, reporting each
+// capture it starts on started.
+type blockingCapture struct {
+	started chan struct{}
+}
+
+func (c *blockingCapture) CaptureForecast(
+	ctx context.Context,
+	_ domain.CaptureRequest,
+) ([]byte, error) {
+	c.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestNextRun(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name string
+		sub  domain.Subscription
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "midnight later today",
+			sub:  domain.Subscription{Time: timeOfDay(0, 0)},
+			now:  time.Date(2024, time.May, 1, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "exactly at midnight moves to the next day",
+			sub:  domain.Subscription{Time: timeOfDay(0, 0)},
+			now:  time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "just after midnight waits for the time later today",
+			sub:  domain.Subscription{Time: timeOfDay(23, 30)},
+			now:  time.Date(2024, time.May, 2, 0, 0, 1, 0, time.UTC),
+			want: time.Date(2024, time.May, 2, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "midnight in the subscription's timezone",
+			sub:  domain.Subscription{Time: timeOfDay(0, 0), Timezone: "Asia/Tokyo"},
+			now:  time.Date(2024, time.May, 1, 14, 59, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 1, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "midnight across a month and year end",
+			sub:  domain.Subscription{Time: timeOfDay(0, 0)},
+			now:  time.Date(2024, time.December, 31, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "daily across the start of daylight saving time",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), Timezone: "America/New_York"},
+			now:  time.Date(2024, time.March, 9, 9, 0, 0, 0, newYork),
+			want: time.Date(2024, time.March, 10, 8, 0, 0, 0, newYork),
+		},
+		{
+			name: "daily across the end of daylight saving time",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), Timezone: "America/New_York"},
+			now:  time.Date(2024, time.November, 2, 9, 0, 0, 0, newYork),
+			want: time.Date(2024, time.November, 3, 8, 0, 0, 0, newYork),
+		},
+		{
+			name: "skipped days across daylight saving time",
+			sub: domain.Subscription{
+				Time:     timeOfDay(8, 0),
+				Timezone: "America/New_York",
+				Days:     1 << time.Monday,
+			},
+			now:  time.Date(2024, time.March, 8, 9, 0, 0, 0, newYork),
+			want: time.Date(2024, time.March, 11, 8, 0, 0, 0, newYork),
+		},
+		{
+			name: "every two days across daylight saving time",
+			sub: domain.Subscription{
+				Time:     timeOfDay(8, 0),
+				Timezone: "America/New_York",
+				EveryN:   48 * time.Hour,
+			},
+			now:  time.Date(2024, time.March, 9, 9, 0, 0, 0, newYork),
+			want: time.Date(2024, time.March, 11, 8, 0, 0, 0, newYork),
+		},
+		{
+			name: "6h exactly on a boundary before the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 6 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 14, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "6h just past a boundary before the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 6 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 14, 0, 0, 1, time.UTC),
+			want: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "6h just before a boundary before the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 6 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 1, 59, 59, 0, time.UTC),
+			want: time.Date(2024, time.May, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "6h exactly on the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 6 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "6h just past the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 6 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 20, 0, 0, 1, time.UTC),
+			want: time.Date(2024, time.May, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "12h exactly on a boundary after the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), EveryN: 12 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "12h just past a boundary after the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), EveryN: 12 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 20, 0, 1, 0, time.UTC),
+			want: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "12h exactly on the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), EveryN: 12 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "12h just past the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0), EveryN: 12 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 8, 0, 0, 1, time.UTC),
+			want: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "12h exactly on a boundary before the anchor",
+			sub:  domain.Subscription{Time: timeOfDay(20, 0), EveryN: 12 * time.Hour},
+			now:  time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "no timezone is scheduled in UTC",
+			sub:  domain.Subscription{Time: timeOfDay(8, 0)},
+			now:  time.Date(2024, time.May, 1, 9, 0, 0, 0, newYork),
+			want: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	manager, _, _ := newTestManager(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := usecase.NextRun(manager, tt.sub, tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextRun(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowingRunKeepsIntervals(t *testing.T) {
+	tests := []struct {
+		name  string
+		sub   domain.Subscription
+		first time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "5h across midnight",
+			sub:   domain.Subscription{Time: timeOfDay(8, 0), EveryN: 5 * time.Hour},
+			first: time.Date(2024, time.May, 1, 18, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.May, 1, 23, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 2, 4, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 2, 14, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "18h",
+			sub:   domain.Subscription{Time: timeOfDay(8, 0), EveryN: 18 * time.Hour},
+			first: time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.May, 2, 2, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 2, 20, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 3, 14, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 4, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "18h skipping the weekend",
+			sub: domain.Subscription{
+				Time:   timeOfDay(8, 0),
+				EveryN: 18 * time.Hour,
+				Days:   domain.WeekdaysWorkweek,
+			},
+			first: time.Date(2024, time.May, 3, 8, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.May, 6, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 7, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "6h realigned to the time of day",
+			sub:   domain.Subscription{Time: timeOfDay(8, 0), EveryN: 6 * time.Hour},
+			first: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.May, 2, 2, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	manager, _, _ := newTestManager(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := tt.first
+			for _, want := range tt.want {
+				// Deliveries finish a little after they were due.
+				got := usecase.FollowingRun(manager, tt.sub, previous, previous.Add(time.Minute))
+				if !got.Equal(want) {
+					t.Fatalf("followingRun(%v) = %v, want %v", previous, got, want)
+				}
+				previous = got
+			}
+		})
+	}
+}
+
+func TestRemoveStopsSchedule(t *testing.T) {
+	store := &usecasetest.FakeStore{}
+	manager, _, sender := newTestManager(
+		t,
+		usecase.WithSubscriptionStore(store),
+		usecase.WithShutdownTimeout(time.Minute),
+	)
+
+	if err := manager.Add(testSubscription("channel", 8)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := manager.Add(testSubscription("other", 8)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, "the schedules to record their next run", func() bool {
+		subs, _ := store.List(context.Background())
+		return len(subs) == 2 && !subs[0].NextRunAt.IsZero() && !subs[1].NextRunAt.IsZero()
+	})
+
+	removed, err := manager.Remove("channel")
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Remove = %d, want 1", removed)
+	}
+	statuses, _ := manager.ListStatusByChannel(context.Background(), "channel")
+	if len(statuses) != 0 {
+		t.Errorf("channel still schedules %d subscriptions", len(statuses))
+	}
+	if subs, _ := store.ListByChannel(context.Background(), "channel"); len(subs) != 0 {
+		t.Errorf("store still holds %d subscriptions for the channel", len(subs))
+	}
+
+	// Shutdown waits up to a minute for running schedules, so it only returns promptly when the
+	// removed schedule's goroutine has exited and the remaining one stops at once.
+	done := make(chan int)
+	go func() { done <- manager.Shutdown() }()
+	select {
+	case cancelled := <-done:
+		if cancelled != 1 {
+			t.Errorf("Shutdown cancelled %d subscriptions, want 1", cancelled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown is still waiting for a removed schedule")
+	}
+	if deliveries := sender.Deliveries(); len(deliveries) != 0 {
+		t.Errorf("got %d deliveries, want none", len(deliveries))
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		started bool
+	}{
+		{name: "before Start", started: false},
+		{name: "after Start", started: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &usecasetest.FakeStore{}
+			manager, _, _ := newTestManager(t, usecase.WithSubscriptionStore(store))
+
+			for _, sub := range []domain.Subscription{
+				testSubscription("first", 8),
+				testSubscription("first", 9),
+				testSubscription("second", 8),
+			} {
+				if err := manager.Add(sub); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+			if tt.started {
+				if err := manager.Start(context.Background()); err != nil {
+					t.Fatalf("Start: %v", err)
+				}
+			}
+
+			if cancelled := manager.Shutdown(); cancelled != 3 {
+				t.Errorf("Shutdown = %d, want 3", cancelled)
+			}
+			for _, channelID := range []string{"first", "second"} {
+				statuses, _ := manager.ListStatusByChannel(context.Background(), channelID)
+				if len(statuses) != 0 {
+					t.Errorf("%s still schedules %d subscriptions", channelID, len(statuses))
+				}
+			}
+			err := manager.Add(testSubscription("third", 8))
+			if !errors.Is(err, usecase.ErrManagerClosed) {
+				t.Errorf("Add after Shutdown = %v, want ErrManagerClosed", err)
+			}
+			if err := manager.Start(context.Background()); !errors.Is(err, usecase.ErrManagerClosed) {
+				t.Errorf("Start after Shutdown = %v, want ErrManagerClosed", err)
+			}
+			if subs, _ := store.List(context.Background()); len(subs) != 3 {
+				t.Errorf("store holds %d subscriptions, want all 3 kept for the next start", len(subs))
+			}
+			if cancelled := manager.Shutdown(); cancelled != 0 {
+				t.Errorf("second Shutdown = %d, want 0", cancelled)
+			}
+		})
+	}
+}
+
+func TestShutdownCancelsDeliveriesInProgress(t *testing.T) {
+	capture := &blockingCapture{started: make(chan struct{}, 1)}
+	manager := usecase.NewSubscriptionManager(
+		capture,
+		usecasetest.NewFakeSender(1),
+		usecase.WithShutdownTimeout(10*time.Millisecond),
+	)
+
+	sub := testSubscription("channel", 8)
+	sub.Alignment = domain.AlignToCreation
+	if err := manager.Add(sub); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	select {
+	case <-capture.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the delivery never started")
+	}
+
+	done := make(chan int)
+	go func() { done <- manager.Shutdown() }()
+	select {
+	case cancelled := <-done:
+		if cancelled != 1 {
+			t.Errorf("Shutdown = %d, want 1", cancelled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown did not cancel the capture in progress")
+	}
+}
+
+func TestUpdateRejectsDuplicates(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   bool
+		hour    int
+		wantErr error
+	}{
+		{
+			name:    "same time as another subscription",
+			store:   true,
+			hour:    9,
+			wantErr: usecase.ErrDuplicateSubscription,
+		},
+		{name: "unchanged time of the edited subscription", store: true, hour: 8},
+		{name: "a free time", store: true, hour: 10},
+		{name: "without a store", hour: 9, wantErr: usecase.ErrDuplicateSubscription},
+		{name: "without a store, unchanged", hour: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []usecase.SubscriptionManagerOption
+			if tt.store {
+				opts = append(opts, usecase.WithSubscriptionStore(&usecasetest.FakeStore{}))
+			}
+			manager, _, _ := newTestManager(t, opts...)
+			for _, hour := range []int{8, 9} {
+				if err := manager.Add(testSubscription("channel", hour)); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+
+			edited := testSubscription("channel", tt.hour)
+			edited.Message = "Edited"
+			err := manager.Update(context.Background(), "channel", 1, edited)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update = %v, want %v", err, tt.wantErr)
+			}
+
+			subs, err := manager.ListByChannel(context.Background(), "channel")
+			if err != nil {
+				t.Fatalf("ListByChannel: %v", err)
+			}
+			edits := 0
+			for _, sub := range subs {
+				if sub.Message == "Edited" {
+					edits++
+				}
+			}
+			wantEdits := 1
+			if tt.wantErr != nil {
+				wantEdits = 0
+			}
+			if edits != wantEdits {
+				t.Errorf("%d subscriptions were edited, want %d", edits, wantEdits)
+			}
+		})
+	}
+}
+
+// errorRecorder collects the stages reported to a SubscriptionErrorHandler.
+type errorRecorder struct {
+	mu     sync.Mutex
+	stages []usecase.SubscriptionErrorStage
+}
+
+func (r *errorRecorder) handle(
+	_ domain.Subscription,
+	stage usecase.SubscriptionErrorStage,
+	_ error,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stages = append(r.stages, stage)
+}
+
+func (r *errorRecorder) has(stage usecase.SubscriptionErrorStage) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Contains(r.stages, stage)
+}
+
+func TestRemoveReconcilesStoppedSubscriptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		unscheduled   bool
+		wantRemoved   int
+		wantReconcile bool
+	}{
+		{name: "stopped subscription", wantRemoved: 2},
+		{name: "row without a schedule", unscheduled: true, wantRemoved: 3, wantReconcile: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &usecasetest.FakeStore{}
+			errs := &errorRecorder{}
+			capture := &usecasetest.FakeCapture{Err: errors.New("page unavailable")}
+			manager := usecase.NewSubscriptionManager(
+				capture,
+				usecasetest.NewFakeSender(1),
+				usecase.WithSubscriptionStore(store),
+				usecase.WithMaxConsecutiveFailures(1),
+				usecase.WithSubscriptionErrorHandler(errs.handle),
+			)
+			t.Cleanup(func() { manager.Shutdown() })
+
+			failing := testSubscription("channel", 8)
+			failing.Alignment = domain.AlignToCreation
+			if err := manager.Add(failing); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			paused := testSubscription("channel", 9)
+			paused.Paused = true
+			if err := manager.Add(paused); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if tt.unscheduled {
+				_, err := store.Create(context.Background(), testSubscription("channel", 10))
+				if err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			waitFor(t, "the failing subscription to stop", func() bool {
+				return errs.has(usecase.SubscriptionErrorStageAbort)
+			})
+
+			removed, err := manager.Remove("channel")
+			if err != nil {
+				t.Fatalf("Remove: %v", err)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("Remove = %d, want %d", removed, tt.wantRemoved)
+			}
+			if got := errs.has(usecase.SubscriptionErrorStageReconcile); got != tt.wantReconcile {
+				t.Errorf("reconcile error reported = %t, want %t", got, tt.wantReconcile)
+			}
+		})
+	}
+}
+
+// fakeNotifier records the direct messages sent to subscription owners.
+type fakeNotifier struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (n *fakeNotifier) NotifyUser(_ context.Context, userID, _ string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, userID)
+	return nil
+}
+
+func (n *fakeNotifier) recipients() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]string(nil), n.messages...)
+}
+
+func TestFirstDeliveryIsConfirmedOnceAcrossEdits(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*usecase.SubscriptionManager, domain.Subscription) error
+	}{
+		{
+			name: "transfer",
+			edit: func(manager *usecase.SubscriptionManager, _ domain.Subscription) error {
+				_, err := manager.TransferOwnership(context.Background(), 0, "new-owner")
+				return err
+			},
+		},
+		{
+			name: "update",
+			edit: func(manager *usecase.SubscriptionManager, sub domain.Subscription) error {
+				sub.Message = "Edited"
+				return manager.Update(context.Background(), sub.ChannelID, 1, sub)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := usecasetest.NewFakeClock(time.Date(2024, time.May, 1, 7, 59, 0, 0, time.UTC))
+			notifier := &fakeNotifier{}
+			manager, _, sender := newTestManager(
+				t,
+				usecase.WithSubscriptionClock(clock.Now),
+				usecase.WithClockResyncInterval(time.Millisecond),
+				usecase.WithSubscriberNotifier(notifier),
+			)
+
+			sub := testSubscription("channel", 8)
+			sub.CreatedByUserID = "owner"
+			sub.ConfirmFirstDelivery = true
+			if err := manager.Add(sub); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			waitForNextRun(t, manager, "channel")
+			clock.Advance(time.Minute)
+			receive(t, sender.Delivered)
+			waitFor(t, "the first delivery confirmation", func() bool {
+				return len(notifier.recipients()) == 1
+			})
+
+			if err := tt.edit(manager, sub); err != nil {
+				t.Fatalf("edit: %v", err)
+			}
+			waitForNextRun(t, manager, "channel")
+			clock.Advance(24 * time.Hour)
+			receive(t, sender.Delivered)
+			manager.Shutdown()
+
+			if recipients := notifier.recipients(); len(recipients) != 1 {
+				t.Errorf("sent %d confirmations (%v), want 1", len(recipients), recipients)
+			}
+		})
+	}
+}
+
+// fakeFeatureSource reports features, or fails with err when set.
+type fakeFeatureSource struct {
+	features domain.CaptureFeatures
+	err      error
+}
+
+func (s fakeFeatureSource) CaptureFeatures(context.Context) (domain.CaptureFeatures, error) {
+	return s.features, s.err
+}
+
+func TestUnsupportedCaptureFeatures(t *testing.T) {
+	headersOnly := domain.CaptureFeatures{domain.CaptureFeatureHeaders: true}
+
+	tests := []struct {
+		name        string
+		source      fakeFeatureSource
+		edit        func(*domain.Subscription)
+		wantOptions string
+	}{
+		{
+			name:   "supported option",
+			source: fakeFeatureSource{features: headersOnly},
+			edit:   func(sub *domain.Subscription) { sub.Language = "ja" },
+		},
+		{
+			name:        "unsupported option",
+			source:      fakeFeatureSource{features: headersOnly},
+			edit:        func(sub *domain.Subscription) { sub.Timezone = "Asia/Tokyo" },
+			wantOptions: "timezone",
+		},
+		{
+			name:        "keywords without text extraction",
+			source:      fakeFeatureSource{features: domain.CaptureFeatures{}},
+			edit:        func(sub *domain.Subscription) { sub.Keywords = []string{"rain"} },
+			wantOptions: "keywords",
+		},
+		{
+			name:   "capture service unreachable",
+			source: fakeFeatureSource{err: errors.New("unavailable")},
+			edit:   func(sub *domain.Subscription) { sub.Timezone = "Asia/Tokyo" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := testSubscription("channel", 8)
+			tt.edit(&sub)
+			check := func(action string, err error) {
+				t.Helper()
+				var unsupported *domain.UnsupportedCaptureError
+				switch {
+				case tt.wantOptions == "" && err != nil:
+					t.Fatalf("%s = %v, want nil", action, err)
+				case tt.wantOptions == "":
+				case !errors.As(err, &unsupported):
+					t.Fatalf("%s = %v, want *domain.UnsupportedCaptureError", action, err)
+				case unsupported.Options() != tt.wantOptions:
+					t.Fatalf("%s rejected %q, want %q", action, unsupported.Options(), tt.wantOptions)
+				}
+			}
+
+			added, _, _ := newTestManager(t, usecase.WithCaptureFeatures(tt.source))
+			check("Add", added.Add(sub))
+
+			edited, _, _ := newTestManager(
+				t,
+				usecase.WithSubscriptionStore(&usecasetest.FakeStore{}),
+				usecase.WithCaptureFeatures(tt.source),
+			)
+			if err := edited.Add(testSubscription("channel", 8)); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			check("Update", edited.Update(context.Background(), "channel", 1, sub))
+		})
+	}
+}
+
+// expectNoDelivery fails the test when sender delivers anything within a few resync intervals.
+func expectNoDelivery(t *testing.T, sender *usecasetest.FakeSender) {
+	t.Helper()
+
+	select {
+	case delivery := <-sender.Delivered:
+		t.Fatalf("unexpected delivery %+v", delivery)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClockJumpResync(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// jumps are applied to the clock in order; each delivers when deliver is set.
+		jumps       []time.Time
+		deliver     []bool
+		wantNextRun time.Time
+	}{
+		{
+			name:        "forward past the scheduled time",
+			jumps:       []time.Time{time.Date(2024, time.May, 1, 8, 0, 30, 0, time.UTC)},
+			deliver:     []bool{true},
+			wantNextRun: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "backward before reaching the scheduled time",
+			jumps: []time.Time{
+				time.Date(2024, time.May, 1, 5, 0, 0, 0, time.UTC),
+				time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC),
+			},
+			deliver:     []bool{false, true},
+			wantNextRun: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "forward over several days delivers once",
+			jumps:       []time.Time{time.Date(2024, time.May, 4, 9, 0, 0, 0, time.UTC)},
+			deliver:     []bool{true},
+			wantNextRun: time.Date(2024, time.May, 5, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := usecasetest.NewFakeClock(start)
+			manager, _, sender := newTestManager(
+				t,
+				usecase.WithSubscriptionClock(clock.Now),
+				usecase.WithClockResyncInterval(time.Millisecond),
+			)
+			if err := manager.Add(testSubscription("channel", 8)); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			waitForNextRun(t, manager, "channel")
+
+			for i, jump := range tt.jumps {
+				clock.Set(jump)
+				if tt.deliver[i] {
+					receive(t, sender.Delivered)
+				} else {
+					expectNoDelivery(t, sender)
+				}
+			}
+			expectNoDelivery(t, sender)
+
+			waitFor(t, "the next run after the jump", func() bool {
+				statuses, _ := manager.ListStatusByChannel(context.Background(), "channel")
+				return len(statuses) == 1 && statuses[0].NextRun.Equal(tt.wantNextRun)
+			})
+		})
+	}
+}
+
+func TestRemoveOneDropsEmptyChannels(t *testing.T) {
+	tests := []struct {
+		name  string
+		store bool
+	}{
+		{name: "with a store", store: true},
+		{name: "without a store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []usecase.SubscriptionManagerOption
+			if tt.store {
+				opts = append(opts, usecase.WithSubscriptionStore(&usecasetest.FakeStore{}))
+			}
+			manager, _, _ := newTestManager(t, opts...)
+			for _, sub := range []domain.Subscription{
+				testSubscription("channel", 8),
+				testSubscription("channel", 9),
+				testSubscription("other", 8),
+			} {
+				if err := manager.Add(sub); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+
+			steps := []struct {
+				index        int
+				wantRemoved  bool
+				wantChannels []string
+			}{
+				{index: 3, wantRemoved: false, wantChannels: []string{"channel", "other"}},
+				{index: 1, wantRemoved: true, wantChannels: []string{"channel", "other"}},
+				{index: 1, wantRemoved: true, wantChannels: []string{"other"}},
+				{index: 1, wantRemoved: false, wantChannels: []string{"other"}},
+			}
+			for _, step := range steps {
+				removed, err := manager.RemoveOne(context.Background(), "channel", step.index)
+				if err != nil {
+					t.Fatalf("RemoveOne(%d): %v", step.index, err)
+				}
+				if removed != step.wantRemoved {
+					t.Errorf("RemoveOne(%d) = %t, want %t", step.index, removed, step.wantRemoved)
+				}
+				if got := manager.ScheduledChannels(); !slices.Equal(got, step.wantChannels) {
+					t.Errorf("scheduled channels = %v, want %v", got, step.wantChannels)
+				}
+			}
+		})
+	}
+}
+
+// metricsRecorder is a SubscriptionMetrics keeping the latest value of every metric.
+type metricsRecorder struct {
+	mu          sync.Mutex
+	created     int
+	removed     int
+	active      int
+	rateLimited int
+	captures    int
+	dispatches  int
+}
+
+func (r *metricsRecorder) SubscriptionCreated() {
+	r.update(func() { r.created++ })
+}
+
+func (r *metricsRecorder) SubscriptionsRemoved(count int) {
+	r.update(func() { r.removed += count })
+}
+
+func (r *metricsRecorder) SetActiveSubscriptions(count int) {
+	r.update(func() { r.active = count })
+}
+
+func (r *metricsRecorder) CaptureRateLimited() {
+	r.update(func() { r.rateLimited++ })
+}
+
+func (r *metricsRecorder) CaptureAttempted() {
+	r.update(func() { r.captures++ })
+}
+
+func (r *metricsRecorder) DispatchAttempted() {
+	r.update(func() { r.dispatches++ })
+}
+
+func (r *metricsRecorder) update(change func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change()
+}
+
+// snapshot returns created, removed, active, captures and dispatches.
+func (r *metricsRecorder) snapshot() [5]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return [5]int{r.created, r.removed, r.active, r.captures, r.dispatches}
+}
+
+func TestSubscriptionLifecycleMetrics(t *testing.T) {
+	clock := usecasetest.NewFakeClock(time.Date(2024, time.May, 1, 7, 0, 0, 0, time.UTC))
+	metrics := &metricsRecorder{}
+	manager, _, sender := newTestManager(
+		t,
+		usecase.WithSubscriptionStore(&usecasetest.FakeStore{}),
+		usecase.WithSubscriptionClock(clock.Now),
+		usecase.WithClockResyncInterval(time.Millisecond),
+		usecase.WithSubscriptionMetrics(metrics),
+	)
+
+	// Each step is followed by the expected created, removed, active, captures and dispatches.
+	steps := []struct {
+		name string
+		run  func(t *testing.T)
+		want [5]int
+	}{
+		{
+			name: "add",
+			run: func(t *testing.T) {
+				for _, sub := range []domain.Subscription{
+					testSubscription("channel", 8),
+					testSubscription("channel", 9),
+					testSubscription("other", 8),
+				} {
+					if err := manager.Add(sub); err != nil {
+						t.Fatalf("Add: %v", err)
+					}
+				}
+			},
+			want: [5]int{3, 0, 3, 0, 0},
+		},
+		{
+			name: "rejected duplicate",
+			run: func(t *testing.T) {
+				if err := manager.Add(testSubscription("other", 8)); err == nil {
+					t.Fatal("Add of a duplicate succeeded")
+				}
+			},
+			want: [5]int{3, 0, 3, 0, 0},
+		},
+		{
+			name: "deliver",
+			run: func(t *testing.T) {
+				if err := manager.Start(context.Background()); err != nil {
+					t.Fatalf("Start: %v", err)
+				}
+				waitForNextRun(t, manager, "channel")
+				waitForNextRun(t, manager, "other")
+				clock.Set(time.Date(2024, time.May, 1, 8, 0, 30, 0, time.UTC))
+				receive(t, sender.Delivered)
+				receive(t, sender.Delivered)
+			},
+			want: [5]int{3, 0, 3, 2, 2},
+		},
+		{
+			name: "remove one",
+			run: func(t *testing.T) {
+				if _, err := manager.RemoveOne(context.Background(), "channel", 1); err != nil {
+					t.Fatalf("RemoveOne: %v", err)
+				}
+			},
+			want: [5]int{3, 1, 2, 2, 2},
+		},
+		{
+			name: "remove channel",
+			run: func(t *testing.T) {
+				if _, err := manager.Remove("other"); err != nil {
+					t.Fatalf("Remove: %v", err)
+				}
+			},
+			want: [5]int{3, 2, 1, 2, 2},
+		},
+		{
+			name: "shutdown",
+			run:  func(*testing.T) { manager.Shutdown() },
+			want: [5]int{3, 2, 0, 2, 2},
+		},
+	}
+
+	for _, step := range steps {
+		step.run(t)
+		waitFor(t, fmt.Sprintf("metrics %v after %s", step.want, step.name), func() bool {
+			return metrics.snapshot() == step.want
+		})
+	}
+}
+
+// TestShutdownRacesAdd is meant to be run with -race: every Add racing Shutdown must either be
+// cancelled by it or rejected with ErrManagerClosed.
+func TestShutdownRacesAdd(t *testing.T) {
+	const (
+		adders       = 8
+		addsPerAdder = 25
+		// shutdownDelay lets some adds through before Shutdown, so both outcomes occur.
+		shutdownDelay = 50 * time.Microsecond
+	)
+
+	for _, started := range []bool{false, true} {
+		t.Run(fmt.Sprintf("started=%t", started), func(t *testing.T) {
+			manager, _, _ := newTestManager(t)
+			if started {
+				if err := manager.Start(context.Background()); err != nil {
+					t.Fatalf("Start: %v", err)
+				}
+			}
+
+			var (
+				wg       sync.WaitGroup
+				mu       sync.Mutex
+				accepted int
+			)
+			begin := make(chan struct{})
+			for adder := range adders {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-begin
+					for i := range addsPerAdder {
+						channelID := fmt.Sprintf("channel-%d-%d", adder, i)
+						err := manager.Add(testSubscription(channelID, 8))
+						switch {
+						case err == nil:
+							mu.Lock()
+							accepted++
+							mu.Unlock()
+						case !errors.Is(err, usecase.ErrManagerClosed):
+							t.Errorf("Add: %v", err)
+						}
+					}
+				}()
+			}
+
+			cancelled := make(chan int)
+			go func() {
+				<-begin
+				time.Sleep(shutdownDelay)
+				cancelled <- manager.Shutdown()
+			}()
+			close(begin)
+			wg.Wait()
+			total := receive(t, cancelled)
+
+			if total != accepted {
+				t.Errorf("Shutdown cancelled %d subscriptions, but %d were added", total, accepted)
+			}
+			if channels := manager.ScheduledChannels(); len(channels) != 0 {
+				t.Errorf("%d channels are still scheduled after Shutdown", len(channels))
+			}
+			err := manager.Add(testSubscription("late", 8))
+			if !errors.Is(err, usecase.ErrManagerClosed) {
+				t.Errorf("Add after Shutdown = %v, want %v", err, usecase.ErrManagerClosed)
+			}
+		})
+	}
+}
+
+func TestDeliveryLag(t *testing.T) {
+	scheduled := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		delay  time.Duration
+		paused bool
+	}{
+		{name: "on time"},
+		{name: "late", delay: 90 * time.Second},
+		{name: "after a long stall", delay: 3 * time.Hour},
+		{name: "paused subscriptions are not reported", delay: time.Minute, paused: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := usecasetest.NewFakeClock(scheduled.Add(-time.Hour))
+			lags := make(chan time.Duration, 1)
+			manager, _, _ := newTestManager(
+				t,
+				usecase.WithSubscriptionClock(clock.Now),
+				usecase.WithClockResyncInterval(time.Millisecond),
+				usecase.WithDeliveryLagHandler(func(_ domain.Subscription, lag time.Duration) {
+					lags <- lag
+				}),
+			)
+			sub := testSubscription("channel", 8)
+			sub.Paused = tt.paused
+			if err := manager.Add(sub); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			waitForNextRun(t, manager, "channel")
+
+			// The clock only reaches the schedule late, as when the process was starved.
+			clock.Set(scheduled.Add(tt.delay))
+			if tt.paused {
+				waitFor(t, "the paused run to be skipped", func() bool {
+					statuses, _ := manager.ListStatusByChannel(context.Background(), "channel")
+					return len(statuses) == 1 && statuses[0].NextRun.After(scheduled)
+				})
+				select {
+				case lag := <-lags:
+					t.Fatalf("reported a lag of %v for a paused subscription", lag)
+				default:
+				}
+				return
+			}
+			if lag := receive(t, lags); lag != tt.delay {
+				t.Errorf("lag = %v, want %v", lag, tt.delay)
+			}
+		})
+	}
+}
+
+// waitForNextRunAt waits until the only schedule of channelID is next delivered at want.
+func waitForNextRunAt(
+	t *testing.T,
+	manager *usecase.SubscriptionManager,
+	channelID string,
+	want time.Time,
+) {
+	t.Helper()
+
+	waitFor(t, fmt.Sprintf("the next run of %s at %v", channelID, want), func() bool {
+		statuses, _ := manager.ListStatusByChannel(context.Background(), channelID)
+		return len(statuses) == 1 && statuses[0].NextRun.Equal(want)
+	})
+}
+
+func TestAlignment(t *testing.T) {
+	created := time.Date(2024, time.May, 1, 7, 20, 15, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		initial   domain.Alignment
+		alignment domain.Alignment
+		delay     time.Duration
+		timezone  string
+		wantFirst time.Time
+		wantTime  string
+	}{
+		{
+			name:      "wall clock",
+			alignment: domain.AlignToWallClock,
+			wantFirst: time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC),
+			wantTime:  "08:00:00",
+		},
+		{
+			name:      "creation",
+			alignment: domain.AlignToCreation,
+			wantFirst: created,
+			wantTime:  "07:20:15",
+		},
+		{
+			name:      "creation after a start delay",
+			alignment: domain.AlignToCreation,
+			delay:     10 * time.Minute,
+			wantFirst: created.Add(10 * time.Minute),
+			wantTime:  "07:30:15",
+		},
+		{
+			name:      "creation in the subscription's timezone",
+			alignment: domain.AlignToCreation,
+			delay:     10 * time.Minute,
+			timezone:  "Asia/Tokyo",
+			wantFirst: created.Add(10 * time.Minute),
+			wantTime:  "16:30:15",
+		},
+		{
+			name:      "creation as the manager's default",
+			initial:   domain.AlignToCreation,
+			delay:     10 * time.Minute,
+			wantFirst: created.Add(10 * time.Minute),
+			wantTime:  "07:30:15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := usecasetest.NewFakeClock(created)
+			opts := []usecase.SubscriptionManagerOption{
+				usecase.WithSubscriptionClock(clock.Now),
+				usecase.WithClockResyncInterval(time.Millisecond),
+			}
+			if tt.initial != "" {
+				opts = append(opts, usecase.WithInitialAlignment(tt.initial))
+			}
+			manager, _, sender := newTestManager(t, opts...)
+
+			sub := testSubscription("channel", 8)
+			sub.Alignment = tt.alignment
+			sub.StartDelay = tt.delay
+			sub.Timezone = tt.timezone
+			if err := manager.Add(sub); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			if tt.wantFirst.After(created) {
+				waitForNextRunAt(t, manager, "channel", tt.wantFirst)
+				expectNoDelivery(t, sender)
+				clock.Set(tt.wantFirst)
+			}
+			receive(t, sender.Delivered)
+			waitForNextRunAt(t, manager, "channel", tt.wantFirst.AddDate(0, 0, 1))
+
+			subs, err := manager.ListByChannel(context.Background(), "channel")
+			if err != nil {
+				t.Fatalf("ListByChannel: %v", err)
+			}
+			if got := subs[0].Time.Format(time.TimeOnly); got != tt.wantTime {
+				t.Errorf("Time = %s, want %s", got, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestStartAndClose(t *testing.T) {
+	t.Run("schedules run from Start, once", func(t *testing.T) {
+		clock := usecasetest.NewFakeClock(time.Date(2024, time.May, 1, 7, 0, 0, 0, time.UTC))
+		manager, capture, sender := newTestManager(
+			t,
+			usecase.WithSubscriptionClock(clock.Now),
+			usecase.WithClockResyncInterval(time.Millisecond),
+		)
+		if err := manager.Add(testSubscription("channel", 8)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		expectNoDelivery(t, sender)
+		statuses, _ := manager.ListStatusByChannel(context.Background(), "channel")
+		if len(statuses) != 1 || !statuses[0].NextRun.IsZero() {
+			t.Fatalf("statuses before Start = %+v, want one without a next run", statuses)
+		}
+
+		for range 2 {
+			if err := manager.Start(context.Background()); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+		}
+		first := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+		waitForNextRunAt(t, manager, "channel", first)
+		clock.Set(first)
+		receive(t, sender.Delivered)
+		expectNoDelivery(t, sender)
+		if requests := capture.Requests(); len(requests) != 1 {
+			t.Errorf("captured %d times, want once", len(requests))
+		}
+	})
+
+	t.Run("cancelling Start's context shuts down", func(t *testing.T) {
+		manager, _, _ := newTestManager(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := manager.Start(ctx); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if err := manager.Add(testSubscription("channel", 8)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		cancel()
+		waitFor(t, "the manager to shut down", func() bool {
+			return errors.Is(manager.Add(testSubscription("other", 8)), usecase.ErrManagerClosed)
+		})
+		if channels := manager.ScheduledChannels(); len(channels) != 0 {
+			t.Errorf("channels %v are still scheduled", channels)
+		}
+	})
+
+	t.Run("Close shuts down", func(t *testing.T) {
+		manager, _, _ := newTestManager(t)
+		if err := manager.Add(testSubscription("channel", 8)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := manager.Start(context.Background()); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		for range 2 {
+			if err := manager.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+		}
+		if channels := manager.ScheduledChannels(); len(channels) != 0 {
+			t.Errorf("channels %v are still scheduled", channels)
+		}
+		err := manager.Add(testSubscription("other", 8))
+		if !errors.Is(err, usecase.ErrManagerClosed) {
+			t.Errorf("Add after Close = %v, want ErrManagerClosed", err)
+		}
+	})
+}
+
+func TestLoadExistingResumesRecordedRuns(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	store := &usecasetest.FakeStore{}
+
+	// Each subscription is stored in its own channel, recorded before a restart at now.
+	stored := []struct {
+		channelID string
+		every     time.Duration
+		nextRunAt time.Time
+		want      time.Time
+	}{
+		{
+			// Stepping on from 08:00 the day before rather than from today's 08:00.
+			channelID: "18h",
+			every:     18 * time.Hour,
+			nextRunAt: time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			channelID: "every other day",
+			every:     48 * time.Hour,
+			nextRunAt: time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			channelID: "long missed",
+			nextRunAt: time.Date(2024, time.April, 1, 8, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, time.May, 2, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, s := range stored {
+		sub := testSubscription(s.channelID, 8)
+		sub.EveryN = s.every
+		created, err := store.Create(context.Background(), sub)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := store.UpdateNextRun(context.Background(), created.ID, s.nextRunAt); err != nil {
+			t.Fatalf("UpdateNextRun: %v", err)
+		}
+	}
+
+	manager, _, _ := newTestManager(
+		t,
+		usecase.WithSubscriptionStore(store),
+		usecase.WithSubscriptionClock(usecasetest.NewFakeClock(now).Now),
+	)
+	if err := manager.LoadExisting(context.Background()); err != nil {
+		t.Fatalf("LoadExisting: %v", err)
+	}
+	if err := manager.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	for _, s := range stored {
+		waitForNextRunAt(t, manager, s.channelID, s.want)
+	}
+}
+
+// slowStore is a FakeStore whose Create takes a while, as a database insert does.
+type slowStore struct {
+	*usecasetest.FakeStore
+}
+
+func (s slowStore) Create(
+	ctx context.Context,
+	subscription domain.Subscription,
+) (domain.Subscription, error) {
+	time.Sleep(5 * time.Millisecond)
+	return s.FakeStore.Create(ctx, subscription)
+}
+
+func TestConcurrentDuplicateAdd(t *testing.T) {
+	store := &usecasetest.FakeStore{}
+	manager, _, _ := newTestManager(t, usecase.WithSubscriptionStore(slowStore{store}))
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() { errs <- manager.Add(testSubscription("channel", 8)) }()
+	}
+	var added, duplicates int
+	for range 2 {
+		switch err := receive(t, errs); {
+		case err == nil:
+			added++
+		case errors.Is(err, usecase.ErrDuplicateSubscription):
+			duplicates++
+		default:
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if added != 1 || duplicates != 1 {
+		t.Fatalf("added %d and rejected %d duplicates, want 1 each", added, duplicates)
+	}
+
+	stored, err := store.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Errorf("stored %d subscriptions, want 1", len(stored))
+	}
+	if got := manager.ScheduledChannels(); !slices.Equal(got, []string{"channel"}) {
+		t.Errorf("scheduled channels = %v, want [channel]", got)
+	}
+}
+
+func TestFailedAddReleasesDuplicateCheck(t *testing.T) {
+	store := &usecasetest.FakeStore{Err: errors.New("database is locked")}
+	manager, _, _ := newTestManager(t, usecase.WithSubscriptionStore(store))
+
+	if err := manager.Add(testSubscription("channel", 8)); err == nil {
+		t.Fatal("Add with a failing store succeeded")
+	}
+	store.Err = nil
+	if err := manager.Add(testSubscription("channel", 8)); err != nil {
+		t.Fatalf("Add after the store recovered: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package usecasetest provides in-memory doubles of the use case interfaces for exercising a
+// SubscriptionManager without a capture service or Discord.
+package usecasetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+)
+
+var (
+	_ usecase.ForecastCapture = (*FakeCapture)(nil)
+	_ usecase.ForecastSender  = (*FakeSender)(nil)
+)
+
+// FakeCapture is a usecase.ForecastCapture that returns Image, or Err when set, and records every
+// request it receives.
+type FakeCapture struct {
+	Image []byte
+	Err   error
+
+	mu       sync.Mutex
+	requests []domain.CaptureRequest
+}
+
+// CaptureForecast records req and returns the configured image or error.
+func (f *FakeCapture) CaptureForecast(
+	ctx context.Context,
+	req domain.CaptureRequest,
+) ([]byte, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return f.Image, nil
+}
+
+// Requests returns the capture requests received so far, in order.
+func (f *FakeCapture) Requests() []domain.CaptureRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]domain.CaptureRequest(nil), f.requests...)
+}
+
+// FakeSender is a usecase.ForecastSender that records deliveries and fails with Err when set.
+// Each delivery is also published on Delivered, when it is non-nil, so callers can wait for a
+// scheduled run without sleeping.
+type FakeSender struct {
+	Err       error
+	Delivered chan domain.Delivery
+
+	mu         sync.Mutex
+	deliveries []domain.Delivery
+}
+
+// NewFakeSender returns a FakeSender whose Delivered channel buffers up to buffer deliveries.
+func NewFakeSender(buffer int) *FakeSender {
+	return &FakeSender{Delivered: make(chan domain.Delivery, buffer)}
+}
+
+// SendForecast records delivery and returns the configured error.
+func (f *FakeSender) SendForecast(ctx context.Context, delivery domain.Delivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.deliveries = append(f.deliveries, delivery)
+	f.mu.Unlock()
+
+	if f.Delivered != nil {
+		select {
+		case f.Delivered <- delivery:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.Err
+}
+
+// Deliveries returns the deliveries received so far, in order.
+func (f *FakeSender) Deliveries() []domain.Delivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]domain.Delivery(nil), f.deliveries...)
+}
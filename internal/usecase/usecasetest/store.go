@@ -0,0 +1,235 @@
+package usecasetest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
+	"github.com/sglre6355/weather-lady/internal/usecase"
+)
+
+var _ usecase.SubscriptionStore = (*FakeStore)(nil)
+
+// FakeStore is an in-memory usecase.SubscriptionStore. It assigns IDs from 1 in creation order
+// and lists subscriptions by ID, like the database store. Every method fails with Err when set.
+type FakeStore struct {
+	Err error
+
+	mu            sync.Mutex
+	nextID        uint
+	subscriptions []domain.Subscription
+}
+
+// Create stores subscription under the next ID and returns it.
+func (f *FakeStore) Create(
+	_ context.Context,
+	subscription domain.Subscription,
+) (domain.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return domain.Subscription{}, f.Err
+	}
+	f.nextID++
+	subscription.ID = f.nextID
+	f.subscriptions = append(f.subscriptions, subscription)
+	return subscription, nil
+}
+
+// List returns every stored subscription.
+func (f *FakeStore) List(context.Context) ([]domain.Subscription, error) {
+	return f.filter(func(domain.Subscription) bool { return true })
+}
+
+// ListPaged returns up to limit stored subscriptions starting at offset.
+func (f *FakeStore) ListPaged(
+	ctx context.Context,
+	offset int,
+	limit int,
+) ([]domain.Subscription, error) {
+	subs, err := f.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(subs) {
+		return nil, nil
+	}
+	return subs[offset:min(offset+limit, len(subs))], nil
+}
+
+// FindByID returns the subscription stored under id, or domain.ErrSubscriptionNotFound.
+func (f *FakeStore) FindByID(_ context.Context, id uint) (domain.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return domain.Subscription{}, f.Err
+	}
+	if index := f.indexLocked(id); index >= 0 {
+		return f.subscriptions[index], nil
+	}
+	return domain.Subscription{}, domain.ErrSubscriptionNotFound
+}
+
+// ListByChannel returns the subscriptions stored for channelID.
+func (f *FakeStore) ListByChannel(
+	_ context.Context,
+	channelID string,
+) ([]domain.Subscription, error) {
+	return f.filter(func(sub domain.Subscription) bool { return sub.ChannelID == channelID })
+}
+
+// ListByGuild returns the subscriptions stored for guildID.
+func (f *FakeStore) ListByGuild(_ context.Context, guildID string) ([]domain.Subscription, error) {
+	return f.filter(func(sub domain.Subscription) bool { return sub.GuildID == guildID })
+}
+
+// CountByGuild returns how many subscriptions are stored for guildID.
+func (f *FakeStore) CountByGuild(ctx context.Context, guildID string) (int, error) {
+	subs, err := f.ListByGuild(ctx, guildID)
+	return len(subs), err
+}
+
+// UpdateOwner records userID as the owner of the subscription stored under id.
+func (f *FakeStore) UpdateOwner(_ context.Context, id uint, userID string) error {
+	return f.update(id, func(sub *domain.Subscription) { sub.CreatedByUserID = userID })
+}
+
+// UpdatePaused records whether the subscription stored under id is paused.
+func (f *FakeStore) UpdatePaused(_ context.Context, id uint, paused bool) error {
+	return f.update(id, func(sub *domain.Subscription) { sub.Paused = paused })
+}
+
+// UpdateNextRun records nextRunAt as the next delivery of the subscription stored under id.
+func (f *FakeStore) UpdateNextRun(_ context.Context, id uint, nextRunAt time.Time) error {
+	err := f.update(id, func(sub *domain.Subscription) { sub.NextRunAt = nextRunAt })
+	if errors.Is(err, domain.ErrSubscriptionNotFound) {
+		// The database store updates no rows for a removed subscription without failing.
+		return nil
+	}
+	return err
+}
+
+// ClaimNextRun moves the recorded next delivery of the subscription stored under id from missed
+// to nextRunAt, reporting false when it no longer holds missed.
+func (f *FakeStore) ClaimNextRun(
+	_ context.Context,
+	id uint,
+	missed time.Time,
+	nextRunAt time.Time,
+) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return false, f.Err
+	}
+	index := f.indexLocked(id)
+	if index < 0 || !f.subscriptions[index].NextRunAt.Equal(missed) {
+		return false, nil
+	}
+	f.subscriptions[index].NextRunAt = nextRunAt
+	return true, nil
+}
+
+// ListDueBetween returns the subscriptions whose next delivery falls in [start, end), earliest
+// first.
+func (f *FakeStore) ListDueBetween(
+	_ context.Context,
+	start time.Time,
+	end time.Time,
+) ([]domain.Subscription, error) {
+	subs, err := f.filter(func(sub domain.Subscription) bool {
+		return !sub.NextRunAt.IsZero() && !sub.NextRunAt.Before(start) && sub.NextRunAt.Before(end)
+	})
+	slices.SortStableFunc(subs, func(a, b domain.Subscription) int {
+		return a.NextRunAt.Compare(b.NextRunAt)
+	})
+	return subs, err
+}
+
+// ClearFirstDeliveryConfirmation clears ConfirmFirstDelivery of the subscription stored under id.
+func (f *FakeStore) ClearFirstDeliveryConfirmation(_ context.Context, id uint) error {
+	return f.update(id, func(sub *domain.Subscription) { sub.ConfirmFirstDelivery = false })
+}
+
+// DeleteByChannel removes the subscriptions stored for channelID and returns how many there were.
+func (f *FakeStore) DeleteByChannel(_ context.Context, channelID string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return 0, f.Err
+	}
+	before := len(f.subscriptions)
+	f.subscriptions = slices.DeleteFunc(f.subscriptions, func(sub domain.Subscription) bool {
+		return sub.ChannelID == channelID
+	})
+	return before - len(f.subscriptions), nil
+}
+
+// DeleteByID removes the subscription stored under id and reports whether it existed.
+func (f *FakeStore) DeleteByID(_ context.Context, id uint) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return false, f.Err
+	}
+	index := f.indexLocked(id)
+	if index < 0 {
+		return false, nil
+	}
+	f.subscriptions = slices.Delete(f.subscriptions, index, index+1)
+	return true, nil
+}
+
+// UpdateByID overwrites the subscription stored under subscription.ID, keeping its recorded next
+// run, or returns domain.ErrSubscriptionNotFound.
+func (f *FakeStore) UpdateByID(_ context.Context, subscription domain.Subscription) error {
+	return f.update(subscription.ID, func(sub *domain.Subscription) {
+		subscription.NextRunAt = sub.NextRunAt
+		*sub = subscription
+	})
+}
+
+func (f *FakeStore) filter(keep func(domain.Subscription) bool) ([]domain.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	var subs []domain.Subscription
+	for _, sub := range f.subscriptions {
+		if keep(sub) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+func (f *FakeStore) update(id uint, change func(*domain.Subscription)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return f.Err
+	}
+	index := f.indexLocked(id)
+	if index < 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+	change(&f.subscriptions[index])
+	return nil
+}
+
+func (f *FakeStore) indexLocked(id uint) int {
+	return slices.IndexFunc(f.subscriptions, func(sub domain.Subscription) bool {
+		return sub.ID == id
+	})
+}
@@ -1,28 +1,146 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/sglre6355/weather-lady/internal/domain"
 )
 
 // ForecastProvider captures weather snapshots as raw bytes.
 type ForecastProvider interface {
-	CaptureWeatherForecast(ctx context.Context, url, elementSelector string) ([]byte, error)
+	CaptureWeatherForecast(ctx context.Context, req domain.CaptureRequest) ([]byte, error)
+	// RenderDocument renders a complete HTML document, capturing the element matched by selector
+	// (the whole document when empty), and returns it in the requested format.
+	RenderDocument(
+		ctx context.Context,
+		document string,
+		selector string,
+		format domain.Format,
+	) ([]byte, error)
+	// ExtractText returns the rendered text of the element described by req, or
+	// domain.ErrTextExtractionUnsupported.
+	ExtractText(ctx context.Context, req domain.CaptureRequest) (string, error)
+	// CaptureFeatures returns the optional features the capture service supports.
+	CaptureFeatures(ctx context.Context) (domain.CaptureFeatures, error)
+}
+
+// ForecastFrame is the data available to forecast templates.
+type ForecastFrame struct {
+	// Image is a data URI of the captured element, usable directly as an img src.
+	Image      template.URL
+	SourceURL  string
+	CapturedAt time.Time
 }
 
+// defaultForecastTemplate adds a title header and a capture timestamp footer around the capture.
+var defaultForecastTemplate = template.Must(template.New("forecast").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<style>
+body { margin: 0; background: #ffffff; font-family: sans-serif; color: #1f2933; }
+main { display: inline-block; padding: 16px; }
+header { font-size: 20px; font-weight: bold; margin-bottom: 12px; }
+footer { font-size: 12px; color: #52606d; margin-top: 12px; }
+img { display: block; }
+</style>
+</head>
+<body>
+<main id="forecast">
+<header>Weather forecast</header>
+<img src="{{.Image}}" alt="Weather forecast">
+<footer>Captured {{.CapturedAt.Format "2006-01-02 15:04 MST"}} from {{.SourceURL}}</footer>
+</main>
+</body>
+</html>
+`))
+
+// forecastFrameSelector is the element captured from a rendered template. Templates should wrap
+// their content in an element with this id.
+const forecastFrameSelector = "#forecast"
+
 // WeatherUsecase exposes weather-oriented application actions.
 type WeatherUsecase struct {
 	provider ForecastProvider
+	template *template.Template
+	nowFn    func() time.Time
+}
+
+// WeatherUsecaseOption customises a WeatherUsecase.
+type WeatherUsecaseOption func(*WeatherUsecase)
+
+// WithForecastTemplate replaces the built-in template used for framed captures. The template is
+// executed with a ForecastFrame.
+func WithForecastTemplate(tmpl *template.Template) WeatherUsecaseOption {
+	return func(u *WeatherUsecase) {
+		if tmpl != nil {
+			u.template = tmpl
+		}
+	}
 }
 
 // NewWeatherUsecase wraps the provider to expose higher-level operations.
-func NewWeatherUsecase(provider ForecastProvider) *WeatherUsecase {
-	return &WeatherUsecase{provider: provider}
+func NewWeatherUsecase(provider ForecastProvider, opts ...WeatherUsecaseOption) *WeatherUsecase {
+	u := &WeatherUsecase{
+		provider: provider,
+		template: defaultForecastTemplate,
+		nowFn:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
 }
 
-// CaptureForecast requests a rendered forecast from the provider.
+// ExtractForecastText returns the text of the forecast element described by req.
+func (u *WeatherUsecase) ExtractForecastText(
+	ctx context.Context,
+	req domain.CaptureRequest,
+) (string, error) {
+	return u.provider.ExtractText(ctx, req)
+}
+
+// CaptureFeatures returns the optional features the capture service supports.
+func (u *WeatherUsecase) CaptureFeatures(ctx context.Context) (domain.CaptureFeatures, error) {
+	return u.provider.CaptureFeatures(ctx)
+}
+
+// CaptureForecast requests a rendered forecast from the provider. Framed requests are captured
+// as PNG, embedded in the forecast template and rendered again as a whole.
 func (u *WeatherUsecase) CaptureForecast(
 	ctx context.Context,
-	url, elementSelector string,
+	req domain.CaptureRequest,
 ) ([]byte, error) {
-	return u.provider.CaptureWeatherForecast(ctx, url, elementSelector)
+	if !req.Framed {
+		return u.provider.CaptureWeatherForecast(ctx, req)
+	}
+
+	element := req
+	element.Format = domain.FormatPNG
+	element.Framed = false
+	imageData, err := u.provider.CaptureWeatherForecast(ctx, element)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bytes.Buffer
+	frame := ForecastFrame{
+		Image: template.URL(
+			"data:image/png;base64," + base64.StdEncoding.EncodeToString(imageData),
+		),
+		SourceURL:  req.URL,
+		CapturedAt: u.nowFn(),
+	}
+	if err := u.template.Execute(&document, frame); err != nil {
+		return nil, fmt.Errorf("failed to execute forecast template: %w", err)
+	}
+
+	return u.provider.RenderDocument(ctx, document.String(), forecastFrameSelector, req.Format)
 }